@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
 	"github.com/k14s/kapp/pkg/kapp/logger"
 	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
 	corev1 "k8s.io/api/core/v1"
@@ -72,6 +73,11 @@ func (a *RecordedApp) CreateOrUpdate(labels map[string]string) error {
 				return fmt.Errorf("Getting app: %s", err)
 			}
 
+			// Do not take over ConfigMap holding cluster config
+			if _, found := existingConfigMap.ObjectMeta.Labels[ctlconf.ClusterConfigLabelKey]; found {
+				return fmt.Errorf("Expected app name '%s' to not be used by cluster config ConfigMap", a.name)
+			}
+
 			err = a.mergeAppUpdates(existingConfigMap, labels)
 			if err != nil {
 				return err
@@ -2,8 +2,10 @@ package clusterapply
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
 	ctldiff "github.com/k14s/kapp/pkg/kapp/diff"
 	"github.com/k14s/kapp/pkg/kapp/logger"
 	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
@@ -17,10 +19,14 @@ const (
 	updateStrategyUpdateAnnValue            = ""
 	updateStrategyFallbackOnReplaceAnnValue = "fallback-on-replace"
 	updateStrategyAlwaysReplaceAnnValue     = "always-replace"
+
+	// Comma separated list of owned resource types (example: 'apps/v1/StatefulSet,v1/Pod')
+	ownerRefsAssociationAnnKey = "kapp.k14s.io/owner-references-association"
 )
 
 type AddOrUpdateChangeOpts struct {
 	DefaultUpdateStrategy string
+	PodFailureOpts        ctlresm.PodFailureOpts
 }
 
 type AddOrUpdateChange struct {
@@ -28,7 +34,11 @@ type AddOrUpdateChange struct {
 	identifiedResources ctlres.IdentifiedResources
 	changeFactory       ctldiff.ChangeFactory
 	changeSetFactory    ctldiff.ChangeSetFactory
+	waitRules           []ctlconf.WaitRule
+	ownerRefsAssocRules []ctlconf.OwnerReferenceAssociationRule
 	opts                AddOrUpdateChangeOpts
+
+	events *ConvergedResourceEvents // optional; shared between waited changes
 }
 
 func (c AddOrUpdateChange) Apply() error {
@@ -173,12 +183,58 @@ func (c AddOrUpdateChange) IsDoneApplying() (ctlresm.DoneApplyState, []string, e
 		return ctlresm.DoneApplyState{}, nil, err
 	}
 
-	associatedRs, err := labeledResources.GetAssociated(parentRes)
+	ownedTypes, err := c.ownedResourceTypes(parentRes)
+	if err != nil {
+		return ctlresm.DoneApplyState{Done: true}, nil, err
+	}
+
+	associatedRs, err := labeledResources.GetAssociatedAndOwned(parentRes, ownedTypes)
 	if err != nil {
 		return ctlresm.DoneApplyState{}, nil, err
 	}
 
-	return NewConvergedResource(parentRes, associatedRs).IsDoneApplying()
+	convergedResOpts := ConvergedResourceOpts{
+		WaitRules:        c.waitRules,
+		Events:           c.events,
+		AllEvents:        c.change.Op() == ctldiff.ChangeOpAdd, // all events are new for new resources
+		RelatedResources: NewIdentifiedRelatedResources(c.identifiedResources),
+		ExternalChecks:   true,
+		PodFailure:       &c.opts.PodFailureOpts,
+	}
+
+	return NewConvergedResource(parentRes, associatedRs, convergedResOpts).IsDoneApplying()
+}
+
+// ownedResourceTypes returns types of resources owned via ownerReferences
+// that should be considered as associated resources (opt-in since
+// finding them requires listing resources of these types)
+func (c AddOrUpdateChange) ownedResourceTypes(res ctlres.Resource) ([]ctlres.APIVersionKindMatcher, error) {
+	var result []ctlres.APIVersionKindMatcher
+
+	for _, rule := range c.ownerRefsAssocRules {
+		matchers := ctlconf.ResourceMatchers(rule.ResourceMatchers).AsResourceMatchers()
+		if (ctlres.AnyMatcher{matchers}).Matches(res) {
+			result = append(result, rule.AsOwnedResourceTypes()...)
+		}
+	}
+
+	annVal, found := res.Annotations()[ownerRefsAssociationAnnKey]
+	if !found {
+		return result, nil
+	}
+
+	for _, typeStr := range strings.Split(annVal, ",") {
+		typeStr = strings.TrimSpace(typeStr)
+		idx := strings.LastIndex(typeStr, "/")
+		if idx <= 0 || idx == len(typeStr)-1 {
+			return nil, fmt.Errorf("Expected annotation '%s' on resource '%s' to be a comma separated "+
+				"list of resource types (example: 'apps/v1/StatefulSet,v1/Pod'), but found '%s'",
+				ownerRefsAssociationAnnKey, res.Description(), typeStr)
+		}
+		result = append(result, ctlres.APIVersionKindMatcher{APIVersion: typeStr[:idx], Kind: typeStr[idx+1:]})
+	}
+
+	return result, nil
 }
 
 func (c AddOrUpdateChange) recordAppliedResource(savedRes ctlres.Resource) error {
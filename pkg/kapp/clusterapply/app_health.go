@@ -0,0 +1,117 @@
+package clusterapply
+
+import (
+	"fmt"
+	"strings"
+
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	ctlresm "github.com/k14s/kapp/pkg/kapp/resourcesmisc"
+)
+
+type AppHealthState string
+
+const (
+	AppHealthStateHealthy     AppHealthState = "healthy"
+	AppHealthStateProgressing AppHealthState = "progressing"
+	AppHealthStateDegraded    AppHealthState = "degraded"
+	AppHealthStateUnknown     AppHealthState = "unknown"
+)
+
+var (
+	// Ordered from least to most important
+	appHealthStates = []AppHealthState{
+		AppHealthStateHealthy,
+		AppHealthStateUnknown,
+		AppHealthStateProgressing,
+		AppHealthStateDegraded,
+	}
+)
+
+// AppHealth aggregates convergence states of app's resources
+type AppHealth struct {
+	counts map[AppHealthState]int
+}
+
+func NewAppHealth(resources []ctlres.Resource, waitRules []ctlconf.WaitRule) AppHealth {
+	health := AppHealth{counts: map[AppHealthState]int{}}
+
+	for _, res := range resources {
+		if !res.IsProvisioned() {
+			continue
+		}
+		// Resources that are not waited on do not affect app health
+		if _, found := res.Annotations()[disableWaitAnnKey]; found {
+			continue
+		}
+
+		// External checks are only run while waiting
+		if ctlresm.NewExternalWaitingResource(res, nil, waitRules, false) != nil {
+			health.counts[AppHealthStateUnknown]++
+			continue
+		}
+
+		state, _, err := NewConvergedResource(res, nil, ConvergedResourceOpts{WaitRules: waitRules}).IsDoneApplying()
+
+		switch {
+		case err != nil:
+			health.counts[AppHealthStateUnknown]++
+		case state.Done && state.Successful:
+			health.counts[AppHealthStateHealthy]++
+		case state.Done && !state.Successful:
+			health.counts[AppHealthStateDegraded]++
+		default:
+			health.counts[AppHealthStateProgressing]++
+		}
+	}
+
+	return health
+}
+
+func ParseAppHealthState(str string) (AppHealthState, error) {
+	for _, state := range appHealthStates {
+		if string(state) == str {
+			return state, nil
+		}
+	}
+	return "", fmt.Errorf("Expected app health to be one of: healthy, progressing, degraded, unknown, but was '%s'", str)
+}
+
+// State returns most important state among resources
+// (e.g. single degraded resource makes app degraded)
+func (h AppHealth) State() AppHealthState {
+	if h.Total() == 0 {
+		return AppHealthStateUnknown // nothing to assess
+	}
+	result := AppHealthStateHealthy
+	for _, state := range appHealthStates {
+		if h.counts[state] > 0 {
+			result = state
+		}
+	}
+	return result
+}
+
+func (h AppHealth) Total() int {
+	var total int
+	for _, count := range h.counts {
+		total += count
+	}
+	return total
+}
+
+// CountsString returns non-zero counts (e.g. "5 healthy, 1 progressing")
+func (h AppHealth) CountsString() string {
+	var result []string
+	for _, state := range []AppHealthState{AppHealthStateHealthy, AppHealthStateProgressing,
+		AppHealthStateDegraded, AppHealthStateUnknown} {
+
+		if h.counts[state] > 0 {
+			result = append(result, fmt.Sprintf("%d %s", h.counts[state], state))
+		}
+	}
+	if len(result) == 0 {
+		return "0 resources"
+	}
+	return strings.Join(result, ", ")
+}
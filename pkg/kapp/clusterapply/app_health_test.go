@@ -0,0 +1,149 @@
+package clusterapply_test
+
+import (
+	"testing"
+
+	ctlcap "github.com/k14s/kapp/pkg/kapp/clusterapply"
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+)
+
+func TestAppHealthStateAndCounts(t *testing.T) {
+	resourcesYAML := `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  uid: config-uid
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pending
+  uid: pending-uid
+status:
+  phase: Pending
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: not-waited
+  uid: not-waited-uid
+  annotations:
+    kapp.k14s.io/disable-wait: ""
+status:
+  phase: Failed
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: not-provisioned
+status:
+  phase: Failed
+`
+
+	health := ctlcap.NewAppHealth(buildResources(resourcesYAML, t), nil)
+	expectHealth(t, health, ctlcap.AppHealthStateProgressing, "1 healthy, 1 progressing", 2)
+
+	resourcesYAML += `
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: failed
+  uid: failed-uid
+status:
+  phase: Failed
+`
+
+	health = ctlcap.NewAppHealth(buildResources(resourcesYAML, t), nil)
+	expectHealth(t, health, ctlcap.AppHealthStateDegraded, "1 healthy, 1 progressing, 1 degraded", 3)
+}
+
+func TestAppHealthEmpty(t *testing.T) {
+	health := ctlcap.NewAppHealth(nil, nil)
+	expectHealth(t, health, ctlcap.AppHealthStateUnknown, "0 resources", 0)
+}
+
+func TestAppHealthWaitRules(t *testing.T) {
+	resourcesYAML := `
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: widget
+  uid: widget-uid
+status:
+  conditions:
+  - type: Ready
+    status: "False"
+---
+apiVersion: example.com/v1
+kind: Gadget
+metadata:
+  name: gadget
+  uid: gadget-uid
+`
+
+	configYAML := `
+apiVersion: kapp.k14s.io/v1alpha1
+kind: Config
+waitRules:
+- conditionMatchers:
+  - type: Ready
+    status: "True"
+    success: true
+  resourceMatchers:
+  - apiVersionKindMatcher: {apiVersion: example.com/v1, kind: Widget}
+- externalCheck:
+    command: /bin/false
+  resourceMatchers:
+  - apiVersionKindMatcher: {apiVersion: example.com/v1, kind: Gadget}
+`
+
+	resources := buildResources(resourcesYAML, t)
+
+	// Resources without waiters are considered healthy
+	health := ctlcap.NewAppHealth(resources, nil)
+	expectHealth(t, health, ctlcap.AppHealthStateHealthy, "2 healthy", 2)
+
+	config, err := ctlconf.NewConfigFromResource(ctlres.MustNewResourceFromBytes([]byte(configYAML)))
+	if err != nil {
+		t.Fatalf("Expected config to parse: %s", err)
+	}
+
+	// External checks are not run outside of waiting
+	health = ctlcap.NewAppHealth(resources, config.WaitRules)
+	expectHealth(t, health, ctlcap.AppHealthStateProgressing, "1 progressing, 1 unknown", 2)
+}
+
+func TestParseAppHealthState(t *testing.T) {
+	state, err := ctlcap.ParseAppHealthState("degraded")
+	if err != nil || state != ctlcap.AppHealthStateDegraded {
+		t.Fatalf("Expected state to parse, but was: %s (err: %v)", state, err)
+	}
+
+	_, err = ctlcap.ParseAppHealthState("sick")
+	if err == nil || err.Error() != "Expected app health to be one of: healthy, progressing, degraded, unknown, but was 'sick'" {
+		t.Fatalf("Expected parsing to fail, but was: %v", err)
+	}
+}
+
+func expectHealth(t *testing.T, health ctlcap.AppHealth, state ctlcap.AppHealthState, counts string, total int) {
+	if health.State() != state {
+		t.Fatalf("Expected health state to be '%s', but was '%s'", state, health.State())
+	}
+	if health.CountsString() != counts {
+		t.Fatalf("Expected health counts to be '%s', but was '%s'", counts, health.CountsString())
+	}
+	if health.Total() != total {
+		t.Fatalf("Expected health total to be %d, but was %d", total, health.Total())
+	}
+}
+
+func buildResources(resourcesYAML string, t *testing.T) []ctlres.Resource {
+	resources, err := ctlres.NewFileResource(ctlres.NewBytesSource([]byte(resourcesYAML))).Resources()
+	if err != nil {
+		t.Fatalf("Expected resources to parse: %s", err)
+	}
+	return resources
+}
@@ -69,7 +69,7 @@ func (c *ApplyingChanges) Apply(allChanges []*ctldgraph.Change) ([]WaitingChange
 			applyErrCh <- err
 		}()
 
-		result = append(result, WaitingChange{change, clusterChange})
+		result = append(result, WaitingChange{Graph: change, Cluster: clusterChange})
 	}
 
 	wg.Wait()
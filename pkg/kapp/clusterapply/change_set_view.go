@@ -2,6 +2,7 @@ package clusterapply
 
 import (
 	"github.com/cppforlife/go-cli-ui/ui"
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
 	ctldiff "github.com/k14s/kapp/pkg/kapp/diff"
 )
 
@@ -9,6 +10,13 @@ type ChangeSetViewOpts struct {
 	Summary bool
 	Changes bool
 	ctldiff.TextDiffViewOpts
+
+	WaitRules []ctlconf.WaitRule // used to show reconcile state
+
+	HPAReplicasRebase ctldiff.HPAReplicasRebase // used to explain rebased replicas
+
+	Explain     bool
+	ExplainOpts ResourceExplanationOpts
 }
 
 type ChangeSetView struct {
@@ -27,11 +35,26 @@ func (v *ChangeSetView) Print(ui ui.UI) {
 		for _, view := range v.changeViews {
 			textDiffView := ctldiff.NewTextDiffView(view.TextDiff(), v.opts.TextDiffViewOpts)
 			ui.BeginLinef("--- %s %s\n", applyOpCodeUI[view.ApplyOp()], view.Resource().Description())
+			for _, note := range v.opts.HPAReplicasRebase.Notes(view.AppliedResource(), view.Resource(), view.ExistingResource()) {
+				ui.BeginLinef("    note: %s\n", note)
+			}
 			ui.PrintBlock([]byte(textDiffView.String()))
 		}
 	}
 
-	v.changesView = &ChangesView{ChangeViews: v.changeViews, Sort: true}
+	if v.opts.Explain {
+		var explanations []ResourceExplanation
+		for _, view := range v.changeViews {
+			if view.ApplyOp() != ClusterChangeApplyOpNoop {
+				explanations = append(explanations, NewResourceExplanation(view.Resource(), v.opts.ExplainOpts))
+			}
+		}
+		if len(explanations) > 0 {
+			ResourceExplanationsView{explanations}.Print(ui)
+		}
+	}
+
+	v.changesView = &ChangesView{ChangeViews: v.changeViews, WaitRules: v.opts.WaitRules, Sort: true}
 
 	if v.opts.Summary {
 		v.changesView.Print(ui)
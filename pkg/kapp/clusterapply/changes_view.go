@@ -7,6 +7,7 @@ import (
 	"github.com/cppforlife/go-cli-ui/ui"
 	uitable "github.com/cppforlife/go-cli-ui/ui/table"
 	cmdcore "github.com/k14s/kapp/pkg/kapp/cmd/core"
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
 	ctldiff "github.com/k14s/kapp/pkg/kapp/diff"
 	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
 	"github.com/mitchellh/go-wordwrap"
@@ -15,6 +16,7 @@ import (
 type ChangeView interface {
 	Resource() ctlres.Resource
 	ExistingResource() ctlres.Resource
+	AppliedResource() ctlres.Resource // new resource before rebasing (nil when deleting)
 
 	ApplyOp() ClusterChangeApplyOp
 	WaitOp() ClusterChangeWaitOp
@@ -23,6 +25,7 @@ type ChangeView interface {
 
 type ChangesView struct {
 	ChangeViews []ChangeView
+	WaitRules   []ctlconf.WaitRule
 	Sort        bool
 
 	countsView *ChangesCountsView
@@ -107,7 +110,7 @@ func (v *ChangesView) Print(ui ui.UI) {
 		)
 
 		if resource.IsProvisioned() {
-			syncVal := NewValueResourceConverged(view.ExistingResource())
+			syncVal := NewValueResourceConverged(view.ExistingResource(), v.WaitRules)
 			row = append(row, syncVal.StateVal, syncVal.ReasonVal)
 		} else {
 			row = append(row,
@@ -220,9 +223,9 @@ type ValueResourceConverged struct {
 	ReasonVal uitable.Value
 }
 
-func NewValueResourceConverged(resource ctlres.Resource) ValueResourceConverged {
+func NewValueResourceConverged(resource ctlres.Resource, waitRules []ctlconf.WaitRule) ValueResourceConverged {
 	// TODO state vs err vs output
-	state, _, err := NewConvergedResource(resource, nil).IsDoneApplying()
+	state, _, err := NewConvergedResource(resource, nil, ConvergedResourceOpts{WaitRules: waitRules}).IsDoneApplying()
 	stateUI := NewDoneApplyStateUI(state, err)
 
 	stateVal := uitable.ValueFmt{V: uitable.NewValueString(stateUI.State), Error: stateUI.Error}
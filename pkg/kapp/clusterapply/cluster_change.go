@@ -3,7 +3,9 @@ package clusterapply
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
 	ctldiff "github.com/k14s/kapp/pkg/kapp/diff"
 	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
 	ctlresm "github.com/k14s/kapp/pkg/kapp/resourcesmisc"
@@ -11,6 +13,7 @@ import (
 
 const (
 	disableWaitAnnKey = "kapp.k14s.io/disable-wait" // valid values: ''
+	waitTimeoutAnnKey = "kapp.k14s.io/wait-timeout" // valid values: '30m', '90s', etc.
 )
 
 type ClusterChangeApplyOp string
@@ -44,6 +47,8 @@ type ClusterChange struct {
 	identifiedResources ctlres.IdentifiedResources
 	changeFactory       ctldiff.ChangeFactory
 	changeSetFactory    ctldiff.ChangeSetFactory
+	waitRules           []ctlconf.WaitRule
+	ownerRefsAssocRules []ctlconf.OwnerReferenceAssociationRule
 	ui                  UI
 
 	markedNeedsWaiting bool
@@ -54,10 +59,12 @@ var _ ChangeView = &ClusterChange{}
 func NewClusterChange(change ctldiff.Change, opts ClusterChangeOpts,
 	identifiedResources ctlres.IdentifiedResources,
 	changeFactory ctldiff.ChangeFactory,
-	changeSetFactory ctldiff.ChangeSetFactory, ui UI) *ClusterChange {
+	changeSetFactory ctldiff.ChangeSetFactory,
+	waitRules []ctlconf.WaitRule,
+	ownerRefsAssocRules []ctlconf.OwnerReferenceAssociationRule, ui UI) *ClusterChange {
 
-	return &ClusterChange{change, opts, identifiedResources,
-		changeFactory, changeSetFactory, ui, false}
+	return &ClusterChange{change, opts, identifiedResources, changeFactory,
+		changeSetFactory, waitRules, ownerRefsAssocRules, ui, false}
 }
 
 func (c *ClusterChange) ApplyOp() ClusterChangeApplyOp {
@@ -119,7 +126,8 @@ func (c *ClusterChange) WaitOp() ClusterChangeWaitOp {
 		// TODO associated resources
 		// If existing resource is not in a "done successful" state,
 		// indicate that this will be something we need to wait for
-		existingResState, _, existingErr := NewConvergedResource(c.change.ExistingResource(), nil).IsDoneApplying()
+		existingResState, _, existingErr := NewConvergedResource(
+			c.change.ExistingResource(), nil, ConvergedResourceOpts{WaitRules: c.waitRules}).IsDoneApplying()
 		if existingErr != nil || !(existingResState.Done && existingResState.Successful) {
 			return ClusterChangeWaitOpOK
 		}
@@ -132,6 +140,38 @@ func (c *ClusterChange) WaitOp() ClusterChangeWaitOp {
 
 func (c *ClusterChange) MarkNeedsWaiting() { c.markedNeedsWaiting = true }
 
+// WaitTimeout returns maximum amount of time to wait for this change.
+// Resource annotation takes precedence over matching config wait rules
+// (last matching rule wins); otherwise default timeout is used.
+func (c *ClusterChange) WaitTimeout(defaultTimeout time.Duration) (time.Duration, error) {
+	return resourceWaitTimeout(c.Resource(), c.waitRules, defaultTimeout)
+}
+
+func resourceWaitTimeout(res ctlres.Resource, waitRules []ctlconf.WaitRule, defaultTimeout time.Duration) (time.Duration, error) {
+	if val, found := res.Annotations()[waitTimeoutAnnKey]; found {
+		dur, err := time.ParseDuration(val)
+		if err != nil {
+			return 0, fmt.Errorf("Expected annotation '%s' on resource '%s' to be a duration (example: 30m): %s",
+				waitTimeoutAnnKey, res.Description(), err)
+		}
+		return dur, nil
+	}
+
+	timeout := defaultTimeout
+
+	for _, rule := range waitRules {
+		if rule.Timeout == nil {
+			continue
+		}
+		matchers := ctlconf.ResourceMatchers(rule.ResourceMatchers).AsResourceMatchers()
+		if (ctlres.AnyMatcher{matchers}).Matches(res) {
+			timeout = rule.Timeout.Duration
+		}
+	}
+
+	return timeout, nil
+}
+
 func (c *ClusterChange) Apply() error {
 	op := c.ApplyOp()
 
@@ -139,7 +179,7 @@ func (c *ClusterChange) Apply() error {
 	case ClusterChangeApplyOpAdd, ClusterChangeApplyOpUpdate:
 		return c.applyErr(AddOrUpdateChange{
 			c.change, c.identifiedResources, c.changeFactory,
-			c.changeSetFactory, c.opts.AddOrUpdateChangeOpts}.Apply())
+			c.changeSetFactory, c.waitRules, c.ownerRefsAssocRules, c.opts.AddOrUpdateChangeOpts, nil}.Apply())
 
 	case ClusterChangeApplyOpDelete:
 		return c.applyErr(DeleteChange{c.change, c.identifiedResources}.Apply())
@@ -152,20 +192,21 @@ func (c *ClusterChange) Apply() error {
 	}
 }
 
-func (c *ClusterChange) IsDoneApplying() (ctlresm.DoneApplyState, []string, error) {
-	state, descMsgs, err := c.isDoneApplying()
+// IsDoneApplying optionally uses given events to explain why change is not done yet
+func (c *ClusterChange) IsDoneApplying(events *ConvergedResourceEvents) (ctlresm.DoneApplyState, []string, error) {
+	state, descMsgs, err := c.isDoneApplying(events)
 	primaryDescMsg := fmt.Sprintf("%s: %s", NewDoneApplyStateUI(state, err).State, c.WaitDescription())
 	return state, append([]string{primaryDescMsg}, descMsgs...), err
 }
 
-func (c *ClusterChange) isDoneApplying() (ctlresm.DoneApplyState, []string, error) {
+func (c *ClusterChange) isDoneApplying(events *ConvergedResourceEvents) (ctlresm.DoneApplyState, []string, error) {
 	op := c.WaitOp()
 
 	switch op {
 	case ClusterChangeWaitOpOK:
 		return AddOrUpdateChange{
 			c.change, c.identifiedResources, c.changeFactory,
-			c.changeSetFactory, c.opts.AddOrUpdateChangeOpts}.IsDoneApplying()
+			c.changeSetFactory, c.waitRules, c.ownerRefsAssocRules, c.opts.AddOrUpdateChangeOpts, events}.IsDoneApplying()
 
 	case ClusterChangeWaitOpDelete:
 		return DeleteChange{c.change, c.identifiedResources}.IsDoneApplying()
@@ -188,6 +229,7 @@ func (c *ClusterChange) WaitDescription() string {
 
 func (c *ClusterChange) Resource() ctlres.Resource         { return c.change.NewOrExistingResource() }
 func (c *ClusterChange) ExistingResource() ctlres.Resource { return c.change.ExistingResource() }
+func (c *ClusterChange) AppliedResource() ctlres.Resource  { return c.change.AppliedResource() }
 
 func (c *ClusterChange) TextDiff() ctldiff.TextDiff { return c.change.TextDiff() }
 
@@ -1,6 +1,7 @@
 package clusterapply
 
 import (
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
 	ctldiff "github.com/k14s/kapp/pkg/kapp/diff"
 	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
 )
@@ -10,6 +11,8 @@ type ClusterChangeFactory struct {
 	identifiedResources ctlres.IdentifiedResources
 	changeFactory       ctldiff.ChangeFactory
 	changeSetFactory    ctldiff.ChangeSetFactory
+	waitRules           []ctlconf.WaitRule
+	ownerRefsAssocRules []ctlconf.OwnerReferenceAssociationRule
 	ui                  UI
 }
 
@@ -17,12 +20,15 @@ func NewClusterChangeFactory(
 	opts ClusterChangeOpts,
 	identifiedResources ctlres.IdentifiedResources,
 	changeFactory ctldiff.ChangeFactory,
-	changeSetFactory ctldiff.ChangeSetFactory, ui UI,
+	changeSetFactory ctldiff.ChangeSetFactory,
+	waitRules []ctlconf.WaitRule,
+	ownerRefsAssocRules []ctlconf.OwnerReferenceAssociationRule, ui UI,
 ) ClusterChangeFactory {
-	return ClusterChangeFactory{opts, identifiedResources, changeFactory, changeSetFactory, ui}
+	return ClusterChangeFactory{opts, identifiedResources, changeFactory,
+		changeSetFactory, waitRules, ownerRefsAssocRules, ui}
 }
 
 func (f ClusterChangeFactory) NewClusterChange(change ctldiff.Change) *ClusterChange {
 	return NewClusterChange(change, f.opts, f.identifiedResources,
-		f.changeFactory, f.changeSetFactory, f.ui)
+		f.changeFactory, f.changeSetFactory, f.waitRules, f.ownerRefsAssocRules, f.ui)
 }
@@ -82,7 +82,10 @@ func (c ClusterChangeSet) Apply(changesGraph *ctldgraph.ChangeGraph) error {
 	blockedChanges := ctldgraph.NewBlockedChanges(changesGraph)
 	applyingChanges := NewApplyingChanges(
 		expectedNumChanges, c.opts.ApplyingChangesOpts, c.clusterChangeFactory, c.ui)
-	waitingChanges := NewWaitingChanges(expectedNumChanges, c.opts.WaitingChangesOpts, c.ui)
+	waitingChanges := NewWaitingChanges(expectedNumChanges, c.opts.WaitingChangesOpts,
+		c.clusterChangeFactory.identifiedResources, c.ui)
+
+	defer waitingChanges.Stop()
 
 	for {
 		appliedChanges, err := applyingChanges.Apply(blockedChanges.Unblocked())
@@ -6,6 +6,7 @@ import (
 	"sort"
 
 	"github.com/fatih/color"
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
 	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
 	ctlresm "github.com/k14s/kapp/pkg/kapp/resourcesmisc"
 )
@@ -14,13 +15,25 @@ const (
 	disableAssociatedResourcesWaitingAnnKey = "kapp.k14s.io/disable-associated-resources-wait" // valid value is ''
 )
 
+type ConvergedResourceOpts struct {
+	WaitRules        []ctlconf.WaitRule
+	Events           *ConvergedResourceEvents // optional; used to show recent warning events
+	AllEvents        bool                     // show events that happened before waiting started
+	RelatedResources ctlresm.RelatedResources // optional; used by waiters that inspect other resources
+	ExternalChecks   bool                     // run external checks from wait rules (only while waiting)
+	PodFailure       *ctlresm.PodFailureOpts  // optional; defaults are used if not set
+}
+
 type ConvergedResource struct {
 	res          ctlres.Resource
 	associatedRs []ctlres.Resource
+	opts         ConvergedResourceOpts
 }
 
-func NewConvergedResource(res ctlres.Resource, associatedRs []ctlres.Resource) ConvergedResource {
-	return ConvergedResource{res, associatedRs}
+func NewConvergedResource(res ctlres.Resource, associatedRs []ctlres.Resource,
+	opts ConvergedResourceOpts) ConvergedResource {
+
+	return ConvergedResource{res, associatedRs, opts}
 }
 
 func (c ConvergedResource) IsDoneApplying() (ctlresm.DoneApplyState, []string, error) {
@@ -47,6 +60,8 @@ func (c ConvergedResource) IsDoneApplying() (ctlresm.DoneApplyState, []string, e
 		}
 	}
 
+	descMsgs = append(descMsgs, c.buildEventsDescMsg(convergedRes, uiWaitMsgPrefix)...)
+
 	// If resource explicitly opts out of associated resource waiting
 	// exit quickly with parent resource state or success.
 	// For example, CronJobs should be annotated with this to avoid
@@ -123,6 +138,30 @@ func (c ConvergedResource) findParentAndAssociatedRes() (ctlres.Resource, []ctlr
 }
 
 func (c ConvergedResource) isResourceDoneApplying(res ctlres.Resource) (*ctlresm.DoneApplyState, error) {
+	specificRes := c.specificResource(res)
+	if specificRes == nil {
+		return nil, nil
+	}
+	state := specificRes.IsDoneApplying()
+	return &state, nil
+}
+
+// WaiterName returns name of the waiter used to determine
+// whether resource is done applying (empty if there is none)
+func (c ConvergedResource) WaiterName() string {
+	specificRes := c.specificResource(c.res)
+	if specificRes == nil {
+		return ""
+	}
+	return reflect.Indirect(reflect.ValueOf(specificRes)).Type().Name()
+}
+
+func (c ConvergedResource) specificResource(res ctlres.Resource) SpecificResource {
+	podFailureOpts := ctlresm.DefaultPodFailureOpts()
+	if c.opts.PodFailure != nil {
+		podFailureOpts = *c.opts.PodFailure
+	}
+
 	specificResFactories := []func(ctlres.Resource) SpecificResource{
 		// kapp-controller app resource waiter deals with reconciliation _and_ deletion
 		func(res ctlres.Resource) SpecificResource { return ctlresm.NewKappctrlK14sIoV1alpha1App(res) },
@@ -131,23 +170,45 @@ func (c ConvergedResource) isResourceDoneApplying(res ctlres.Resource) (*ctlresm
 		// TODO shoud we make all of them deal with deletion internally?
 		func(res ctlres.Resource) SpecificResource { return ctlresm.NewDeleting(res) },
 
+		// Custom waiting rules from config take precedence over builtin waiters
+		func(res ctlres.Resource) SpecificResource {
+			return ctlresm.NewCustomWaitingResource(res, c.opts.WaitRules)
+		},
+		func(res ctlres.Resource) SpecificResource {
+			return ctlresm.NewExternalWaitingResource(res, c.associatedRs, c.opts.WaitRules, c.opts.ExternalChecks)
+		},
+
 		func(res ctlres.Resource) SpecificResource { return ctlresm.NewApiExtensionsVxCRD(res) },
-		func(res ctlres.Resource) SpecificResource { return ctlresm.NewCoreV1Pod(res) },
+		func(res ctlres.Resource) SpecificResource { return ctlresm.NewCoreV1Pod(res, podFailureOpts) },
 		func(res ctlres.Resource) SpecificResource { return ctlresm.NewCoreV1Service(res) },
-		func(res ctlres.Resource) SpecificResource { return ctlresm.NewAppsV1Deployment(res, c.associatedRs) },
-		func(res ctlres.Resource) SpecificResource { return ctlresm.NewAppsV1DaemonSet(res) },
+		func(res ctlres.Resource) SpecificResource {
+			return ctlresm.NewAppsV1Deployment(res, c.associatedRs, podFailureOpts)
+		},
+		func(res ctlres.Resource) SpecificResource {
+			return ctlresm.NewAppsV1DaemonSet(res, c.associatedRs, podFailureOpts)
+		},
+		func(res ctlres.Resource) SpecificResource {
+			return ctlresm.NewAppsV1StatefulSet(res, c.associatedRs, podFailureOpts)
+		},
+		func(res ctlres.Resource) SpecificResource {
+			return ctlresm.NewCoreV1PersistentVolumeClaim(res, c.opts.RelatedResources)
+		},
+		func(res ctlres.Resource) SpecificResource { return ctlresm.NewExtensionsAndNetworkingVxIngress(res) },
+		func(res ctlres.Resource) SpecificResource { return ctlresm.NewApiRegistrationVxAPIService(res) },
+		func(res ctlres.Resource) SpecificResource {
+			return ctlresm.NewAdmissionRegistrationVxWebhookConfiguration(res, c.opts.RelatedResources)
+		},
 		func(res ctlres.Resource) SpecificResource { return ctlresm.NewBatchV1Job(res) },
 		func(res ctlres.Resource) SpecificResource { return ctlresm.NewBatchVxCronJob(res) },
 	}
 
 	for _, f := range specificResFactories {
-		if !reflect.ValueOf(f(res)).IsNil() { // checking if interface is nil
-			state := f(res).IsDoneApplying()
-			return &state, nil
+		if specificRes := f(res); !reflect.ValueOf(specificRes).IsNil() { // checking if interface is nil
+			return specificRes
 		}
 	}
 
-	return nil, nil
+	return nil
 }
 
 var (
@@ -170,5 +231,20 @@ func (c ConvergedResource) buildChildDescMsg(res ctlres.Resource, state ctlresm.
 		msgs = append(msgs, uiWaitChildMsgPrefix+state.Message)
 	}
 
+	// Events of resources that are done are most likely no longer relevant
+	if !state.Done {
+		msgs = append(msgs, c.buildEventsDescMsg(res, uiWaitChildMsgPrefix)...)
+	}
+
+	return msgs
+}
+
+func (c ConvergedResource) buildEventsDescMsg(res ctlres.Resource, prefix string) []string {
+	var msgs []string
+	if c.opts.Events != nil {
+		for _, msg := range c.opts.Events.WarningMsgs(res, c.opts.AllEvents) {
+			msgs = append(msgs, prefix+msg)
+		}
+	}
 	return msgs
 }
@@ -0,0 +1,100 @@
+package clusterapply
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	convergedResourceEventsMaxNum = 3
+)
+
+// ConvergedResourceEvents provides recent warning events (e.g. FailedScheduling, BackOff)
+// to help explain why resource is taking time to converge. Events are fetched
+// once per namespace per check (see Reset) and shared between waited resources.
+type ConvergedResourceEvents struct {
+	identifiedResources ctlres.IdentifiedResources
+
+	eventsByNs map[string][]corev1.Event
+	// Latest event time (as recorded by cluster) when namespace was first checked;
+	// used instead of local time to find events that happened while waiting
+	sinceByNs map[string]time.Time
+}
+
+func NewConvergedResourceEvents(identifiedResources ctlres.IdentifiedResources) *ConvergedResourceEvents {
+	return &ConvergedResourceEvents{identifiedResources, map[string][]corev1.Event{}, map[string]time.Time{}}
+}
+
+// Reset forgets fetched events so that they are fetched again during next check
+func (e *ConvergedResourceEvents) Reset() {
+	e.eventsByNs = map[string][]corev1.Event{}
+}
+
+// WarningMsgs returns messages for recent warning events of given resource.
+// Unless allEvents is set, only events that happened after namespace was
+// first checked are included (e.g. events of previous deploys are excluded).
+func (e *ConvergedResourceEvents) WarningMsgs(res ctlres.Resource, allEvents bool) []string {
+	nsEvents, err := e.namespaceEvents(res.Namespace())
+	if err != nil {
+		// Events are shown only for informational purposes,
+		// hence failure to fetch them should not fail waiting
+		return nil
+	}
+
+	since := e.sinceByNs[res.Namespace()]
+
+	var events []corev1.Event
+
+	for _, event := range nsEvents {
+		if string(event.InvolvedObject.UID) != res.UID() {
+			continue
+		}
+		if allEvents || ctlres.EventLastSeen(event).After(since) {
+			events = append(events, event)
+		}
+	}
+
+	if len(events) > convergedResourceEventsMaxNum {
+		events = events[len(events)-convergedResourceEventsMaxNum:]
+	}
+
+	var msgs []string
+
+	for _, event := range events {
+		// Do not include event counts or timestamps so that
+		// repeated events are deduped when shown to the user
+		msgs = append(msgs, fmt.Sprintf("Warning %s: %s", event.Reason, strings.TrimSpace(event.Message)))
+	}
+
+	return msgs
+}
+
+func (e *ConvergedResourceEvents) namespaceEvents(namespace string) ([]corev1.Event, error) {
+	if events, found := e.eventsByNs[namespace]; found {
+		return events, nil
+	}
+
+	events, err := e.identifiedResources.WarningEvents(namespace)
+	if err != nil {
+		e.eventsByNs[namespace] = nil // do not retry until next check
+		return nil, err
+	}
+
+	if _, found := e.sinceByNs[namespace]; !found {
+		var since time.Time
+		for _, event := range events {
+			if lastSeen := ctlres.EventLastSeen(event); lastSeen.After(since) {
+				since = lastSeen
+			}
+		}
+		e.sinceByNs[namespace] = since
+	}
+
+	e.eventsByNs[namespace] = events
+
+	return events, nil
+}
@@ -0,0 +1,128 @@
+package clusterapply
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/k14s/kapp/pkg/kapp/logger"
+	ctllogs "github.com/k14s/kapp/pkg/kapp/logs"
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	failedPodsLogsMaxPods = 3
+	failedPodsLogsTimeout = 10 * time.Second
+)
+
+// FailedPodsLogs collects last log lines of failed or
+// crash looping Pods associated with given resource
+type FailedPodsLogs struct {
+	identifiedResources ctlres.IdentifiedResources
+	lines               int64
+}
+
+func NewFailedPodsLogs(identifiedResources ctlres.IdentifiedResources, lines int64) FailedPodsLogs {
+	return FailedPodsLogs{identifiedResources, lines}
+}
+
+// Collect returns empty string if logs could not be collected
+// since logs are only shown for informational purposes
+func (l FailedPodsLogs) Collect(res ctlres.Resource) string {
+	labeledResources := ctlres.NewLabeledResources(nil, l.identifiedResources, logger.NewTODOLogger())
+
+	// Refresh resource since it may be a Pod itself
+	parentRes, err := l.identifiedResources.Get(res)
+	if err != nil {
+		return ""
+	}
+
+	associatedRs, err := labeledResources.GetAssociated(parentRes)
+	if err != nil {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	bufUI := ui.NewWriterUI(&buf, &buf, ui.NewNoopLogger())
+
+	cancelCh := make(chan struct{})
+	time.AfterFunc(failedPodsLogsTimeout, func() { close(cancelCh) })
+
+	var numPods int
+	seenUIDs := map[string]struct{}{}
+
+	for _, assocRes := range append([]ctlres.Resource{parentRes}, associatedRs...) {
+		if numPods >= failedPodsLogsMaxPods {
+			break
+		}
+
+		// Parent resource is also found among its associated resources
+		if _, found := seenUIDs[assocRes.UID()]; found {
+			continue
+		}
+		seenUIDs[assocRes.UID()] = struct{}{}
+
+		if !(ctlres.APIVersionKindMatcher{APIVersion: "v1", Kind: "Pod"}).Matches(assocRes) {
+			continue
+		}
+
+		pod := corev1.Pod{}
+
+		err := assocRes.AsTypedObj(&pod)
+		if err != nil {
+			continue
+		}
+
+		statuses := l.failedContainerStatuses(pod)
+		if len(statuses) > 0 {
+			numPods++
+		}
+
+		for _, st := range statuses {
+			// Previously terminated container typically has
+			// more useful logs than currently crash looping one
+			if st.RestartCount > 0 {
+				l.tail(pod, st.Name, true, bufUI, cancelCh)
+			}
+			if st.State.Running != nil || st.State.Terminated != nil {
+				l.tail(pod, st.Name, false, bufUI, cancelCh)
+			}
+		}
+	}
+
+	return strings.TrimSpace(buf.String())
+}
+
+func (l FailedPodsLogs) failedContainerStatuses(pod corev1.Pod) []corev1.ContainerStatus {
+	var result []corev1.ContainerStatus
+
+	statuses := append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+
+	for _, st := range statuses {
+		switch {
+		case st.RestartCount > 0:
+			result = append(result, st)
+		case st.State.Terminated != nil && st.State.Terminated.ExitCode != 0:
+			result = append(result, st)
+		}
+	}
+
+	return result
+}
+
+func (l FailedPodsLogs) tail(pod corev1.Pod, container string, previous bool, ui ui.UI, cancelCh chan struct{}) {
+	tag := fmt.Sprintf("%s > %s", pod.Name, container)
+	if previous {
+		tag += " (previous)"
+	}
+
+	logOpts := ctllogs.PodLogOpts{Lines: &l.lines, Previous: previous, LinePrefix: "logs"}
+	podsClient := l.identifiedResources.PodsClient(pod.Namespace)
+
+	// Errors are ignored since logs may not be available (e.g. Pod was deleted)
+	_ = ctllogs.NewPodContainerLog(pod, container, podsClient, tag, logOpts).Tail(ui, cancelCh)
+}
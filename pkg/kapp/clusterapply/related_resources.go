@@ -0,0 +1,27 @@
+package clusterapply
+
+import (
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	ctlresm "github.com/k14s/kapp/pkg/kapp/resourcesmisc"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type IdentifiedRelatedResources struct {
+	identifiedResources ctlres.IdentifiedResources
+}
+
+var _ ctlresm.RelatedResources = IdentifiedRelatedResources{}
+
+func NewIdentifiedRelatedResources(identifiedResources ctlres.IdentifiedResources) IdentifiedRelatedResources {
+	return IdentifiedRelatedResources{identifiedResources}
+}
+
+func (r IdentifiedRelatedResources) Get(apiVersion, kind, namespace, name string) (ctlres.Resource, error) {
+	un := unstructured.Unstructured{}
+	un.SetAPIVersion(apiVersion)
+	un.SetKind(kind)
+	un.SetNamespace(namespace)
+	un.SetName(name)
+
+	return r.identifiedResources.Get(ctlres.NewResourceUnstructured(un, ctlres.ResourceType{}))
+}
@@ -0,0 +1,214 @@
+package clusterapply
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	uitable "github.com/cppforlife/go-cli-ui/ui/table"
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
+	ctldgraph "github.com/k14s/kapp/pkg/kapp/diffgraph"
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+)
+
+type ResourceExplanationOpts struct {
+	Conf                  ctlconf.Conf
+	DefaultUpdateStrategy string
+	WaitTimeout           time.Duration
+}
+
+// ResourceExplanation describes what kapp does with a resource
+// based on matching config rules and resource annotations
+type ResourceExplanation struct {
+	res  ctlres.Resource
+	opts ResourceExplanationOpts
+}
+
+func NewResourceExplanation(res ctlres.Resource, opts ResourceExplanationOpts) ResourceExplanation {
+	return ResourceExplanation{res, opts}
+}
+
+func (e ResourceExplanation) ConfigRules() []string {
+	var result []string
+	for _, rule := range e.opts.Conf.MatchingRules(e.res) {
+		result = append(result, rule.String())
+	}
+	return result
+}
+
+func (e ResourceExplanation) ChangeGroups() ([]string, error) {
+	change := &ctldgraph.Change{Change: explainedActualChange{e.res}}
+
+	groups, err := change.Groups()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, group := range groups {
+		result = append(result, group.Name)
+	}
+	return result, nil
+}
+
+func (e ResourceExplanation) ChangeRules() ([]string, error) {
+	change := &ctldgraph.Change{Change: explainedActualChange{e.res}}
+
+	rules, err := change.AllRules()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, rule := range rules {
+		result = append(result, rule.String())
+	}
+	return result, nil
+}
+
+func (e ResourceExplanation) UpdateStrategy() string {
+	strategy, found := e.res.Annotations()[updateStrategyAnnKey]
+	if found {
+		return fmt.Sprintf("%s (via annotation '%s')", e.strategyName(strategy, "update"), updateStrategyAnnKey)
+	}
+	return fmt.Sprintf("%s (default)", e.strategyName(e.opts.DefaultUpdateStrategy, "update"))
+}
+
+func (e ResourceExplanation) DeleteStrategy() string {
+	strategy, found := e.res.Annotations()[deleteStrategyAnnKey]
+	if found {
+		return fmt.Sprintf("%s (via annotation '%s')", e.strategyName(strategy, "delete"), deleteStrategyAnnKey)
+	}
+	return fmt.Sprintf("%s (default)", e.strategyName(deleteStrategyDefaultAnnKey, "delete"))
+}
+
+// strategyName names default strategies since they are represented by empty values
+func (ResourceExplanation) strategyName(strategy, emptyName string) string {
+	if len(strategy) == 0 {
+		return emptyName
+	}
+	return strategy
+}
+
+func (e ResourceExplanation) LabelScoping() (string, error) {
+	disabled, err := ctlres.IsLabelScopingDisabled(e.res)
+	if err != nil {
+		return "", err
+	}
+	if disabled {
+		return "disabled (via annotation 'kapp.k14s.io/disable-label-scoping')", nil
+	}
+	return "enabled", nil
+}
+
+func (e ResourceExplanation) Waiting() ([]string, error) {
+	if _, found := e.res.Annotations()[disableWaitAnnKey]; found {
+		return []string{fmt.Sprintf("disabled (via annotation '%s')", disableWaitAnnKey)}, nil
+	}
+
+	var result []string
+
+	waitRules := e.opts.Conf.WaitRules()
+
+	waiterName := NewConvergedResource(e.res, nil, ConvergedResourceOpts{WaitRules: waitRules}).WaiterName()
+	if len(waiterName) > 0 {
+		result = append(result, "waiter: "+waiterName)
+	} else {
+		result = append(result, "waiter: none (done once applied unless associated resources are not done)")
+	}
+
+	timeout, err := resourceWaitTimeout(e.res, waitRules, e.opts.WaitTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	result = append(result, fmt.Sprintf("timeout: %s", timeout))
+
+	if _, found := e.res.Annotations()[disableAssociatedResourcesWaitingAnnKey]; found {
+		result = append(result, fmt.Sprintf("associated resources: disabled (via annotation '%s')",
+			disableAssociatedResourcesWaitingAnnKey))
+		return result, nil
+	}
+
+	ownedTypes, err := AddOrUpdateChange{ownerRefsAssocRules: e.opts.Conf.OwnerReferenceAssociationRules()}.ownedResourceTypes(e.res)
+	if err != nil {
+		return nil, err
+	}
+
+	assocDesc := "associated resources: labeled with 'kapp.k14s.io/association'"
+
+	if len(ownedTypes) > 0 {
+		var types []string
+		for _, ownedType := range ownedTypes {
+			types = append(types, ownedType.APIVersion+"/"+ownedType.Kind)
+		}
+		assocDesc += fmt.Sprintf(" and owned via ownerReferences (%s)", strings.Join(types, ", "))
+	}
+
+	return append(result, assocDesc), nil
+}
+
+type explainedActualChange struct {
+	res ctlres.Resource
+}
+
+var _ ctldgraph.ActualChange = explainedActualChange{}
+
+func (c explainedActualChange) Resource() ctlres.Resource    { return c.res }
+func (c explainedActualChange) Op() ctldgraph.ActualChangeOp { return ctldgraph.ActualChangeOpUpsert }
+
+type ResourceExplanationsView struct {
+	Explanations []ResourceExplanation
+}
+
+func (v ResourceExplanationsView) Print(ui ui.UI) {
+	table := uitable.Table{
+		Title:   "Explanations",
+		Content: "resources",
+
+		Header: []uitable.Header{
+			uitable.NewHeader("Namespace"),
+			uitable.NewHeader("Name"),
+			uitable.NewHeader("Kind"),
+			uitable.NewHeader("Config rules"),
+			uitable.NewHeader("Change groups"),
+			uitable.NewHeader("Change rules"),
+			uitable.NewHeader("Update strategy"),
+			uitable.NewHeader("Delete strategy"),
+			uitable.NewHeader("Label scoping"),
+			uitable.NewHeader("Waiting"),
+		},
+
+		Transpose: true,
+	}
+
+	for _, e := range v.Explanations {
+		changeGroups, changeGroupsErr := e.ChangeGroups()
+		changeRules, changeRulesErr := e.ChangeRules()
+		labelScoping, labelScopingErr := e.LabelScoping()
+		waiting, waitingErr := e.Waiting()
+
+		table.Rows = append(table.Rows, []uitable.Value{
+			uitable.NewValueString(e.res.Namespace()),
+			uitable.NewValueString(e.res.Name()),
+			uitable.NewValueString(e.res.Kind()),
+			uitable.NewValueStrings(e.ConfigRules()),
+			v.valueOrErr(uitable.NewValueStrings(changeGroups), changeGroupsErr),
+			v.valueOrErr(uitable.NewValueStrings(changeRules), changeRulesErr),
+			uitable.NewValueString(e.UpdateStrategy()),
+			uitable.NewValueString(e.DeleteStrategy()),
+			v.valueOrErr(uitable.NewValueString(labelScoping), labelScopingErr),
+			v.valueOrErr(uitable.NewValueStrings(waiting), waitingErr),
+		})
+	}
+
+	ui.PrintTable(table)
+}
+
+func (ResourceExplanationsView) valueOrErr(val uitable.Value, err error) uitable.Value {
+	if err != nil {
+		return uitable.ValueFmt{V: uitable.NewValueString(err.Error()), Error: true}
+	}
+	return val
+}
@@ -2,20 +2,29 @@ package clusterapply
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	ctldgraph "github.com/k14s/kapp/pkg/kapp/diffgraph"
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	ctlresm "github.com/k14s/kapp/pkg/kapp/resourcesmisc"
 )
 
 type WaitingChangesOpts struct {
-	Timeout       time.Duration
-	CheckInterval time.Duration
+	Timeout        time.Duration // default per change timeout
+	CheckInterval  time.Duration
+	ResyncInterval time.Duration // checks changes even if no watch events were seen
+
+	FailureLogsLines int64 // number of log lines to show from failed Pods; 0 disables
 }
 
 type WaitingChanges struct {
 	numTotal       int // for ui
 	numWaited      int // for ui
 	trackedChanges []WaitingChange
+	watcher        *WaitingChangesWatcher
+	events         *ConvergedResourceEvents
+	failureLogs    FailedPodsLogs
 	opts           WaitingChangesOpts
 	ui             UI
 }
@@ -23,14 +32,35 @@ type WaitingChanges struct {
 type WaitingChange struct {
 	Graph   *ctldgraph.Change
 	Cluster *ClusterChange
+
+	startedAt time.Time
+	checkedAt time.Time
+	lastState *ctlresm.DoneApplyState
 }
 
-func NewWaitingChanges(numTotal int, opts WaitingChangesOpts, ui UI) *WaitingChanges {
-	return &WaitingChanges{numTotal, 0, nil, opts, ui}
+func NewWaitingChanges(numTotal int, opts WaitingChangesOpts,
+	identifiedResources ctlres.IdentifiedResources, ui UI) *WaitingChanges {
+
+	watcher := NewWaitingChangesWatcher(identifiedResources, ui)
+	events := NewConvergedResourceEvents(identifiedResources)
+	failureLogs := NewFailedPodsLogs(identifiedResources, opts.FailureLogsLines)
+	return &WaitingChanges{numTotal, 0, nil, watcher, events, failureLogs, opts, ui}
 }
 
 func (c *WaitingChanges) Track(changes []WaitingChange) {
-	c.trackedChanges = append(c.trackedChanges, changes...)
+	now := time.Now()
+	for _, change := range changes {
+		change.startedAt = now
+		c.trackedChanges = append(c.trackedChanges, change)
+	}
+
+	if len(changes) > 0 {
+		var resources []ctlres.Resource
+		for _, change := range c.trackedChanges {
+			resources = append(resources, change.Cluster.Resource())
+		}
+		c.watcher.Watch(resources)
+	}
 }
 
 func (c *WaitingChanges) IsEmpty() bool {
@@ -38,37 +68,75 @@ func (c *WaitingChanges) IsEmpty() bool {
 }
 
 func (c *WaitingChanges) WaitForAny() ([]WaitingChange, error) {
-	startTime := time.Now()
-
 	for {
-		c.ui.NotifySection("waiting on %d changes %s", len(c.trackedChanges), c.stats())
-
 		var newInProgressChanges []WaitingChange
 		var doneChanges []WaitingChange
+		var timedOutMsgs []string
+		var notifiedSection bool
+
+		// Events are fetched at most once per namespace per check
+		c.events.Reset()
 
 		for _, change := range c.trackedChanges {
 			desc := fmt.Sprintf("waiting on %s", change.Cluster.WaitDescription())
 
-			state, descMsgs, err := change.Cluster.IsDoneApplying()
-			c.ui.Notify(descMsgs)
+			// Only check changes that were affected by watch events (or were not recently checked)
+			// to avoid fetching resources and their associated resources unnecessarily
+			if c.needsCheck(change) {
+				if !notifiedSection {
+					c.ui.NotifySection("waiting on %d changes %s", len(c.trackedChanges), c.stats())
+					notifiedSection = true
+				}
 
-			if err != nil {
-				return nil, fmt.Errorf("%s: errored: %s", desc, err)
-			}
-			if state.Done {
-				c.numWaited += 1
+				// Events that arrive while checking should trigger another check
+				checkedAt := time.Now()
+
+				state, descMsgs, err := change.Cluster.IsDoneApplying(c.events)
+				c.ui.Notify(descMsgs)
+
+				if err != nil {
+					return nil, fmt.Errorf("%s: errored: %s", desc, err)
+				}
+				if state.Done {
+					c.numWaited += 1
+				}
+
+				change.checkedAt = checkedAt
+				change.lastState = &state
 			}
 
+			state := *change.lastState
+
 			switch {
 			case !state.Done:
 				newInProgressChanges = append(newInProgressChanges, change)
 
+				timeout, err := change.Cluster.WaitTimeout(c.opts.Timeout)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %s", desc, err)
+				}
+
+				if time.Now().Sub(change.startedAt) > timeout {
+					msg := fmt.Sprintf("- %s: timed out after %s", desc, timeout)
+					if len(state.Message) > 0 {
+						msg += " (last message: " + state.Message + ")"
+					}
+					timedOutMsgs = append(timedOutMsgs, msg)
+				}
+
 			case state.Done && !state.Successful:
 				msg := ""
 				if len(state.Message) > 0 {
 					msg += " (" + state.Message + ")"
 				}
-				return nil, fmt.Errorf("%s: finished unsuccessfully%s", desc, msg)
+				err := fmt.Errorf("%s: finished unsuccessfully%s", desc, msg)
+				if c.opts.FailureLogsLines > 0 {
+					// Include logs of failed Pods to help explain failure
+					if logs := c.failureLogs.Collect(change.Cluster.Resource()); len(logs) > 0 {
+						err = fmt.Errorf("%s\n\n%s", err, logs)
+					}
+				}
+				return nil, err
 
 			case state.Done && state.Successful:
 				doneChanges = append(doneChanges, change)
@@ -81,19 +149,38 @@ func (c *WaitingChanges) WaitForAny() ([]WaitingChange, error) {
 			return doneChanges, nil
 		}
 
-		if time.Now().Sub(startTime) > c.opts.Timeout {
-			return nil, fmt.Errorf("timed out waiting after %s", c.opts.Timeout)
+		if len(timedOutMsgs) > 0 {
+			return nil, fmt.Errorf("timed out waiting on %d changes:\n%s",
+				len(timedOutMsgs), strings.Join(timedOutMsgs, "\n"))
 		}
 
 		time.Sleep(c.opts.CheckInterval)
 	}
 }
 
+func (c *WaitingChanges) needsCheck(change WaitingChange) bool {
+	switch {
+	case change.lastState == nil:
+		return true
+	case !c.watcher.IsWatching():
+		return true // fallback to polling
+	case c.opts.ResyncInterval > 0 && time.Now().Sub(change.checkedAt) >= c.opts.ResyncInterval:
+		return true
+	default:
+		return c.watcher.ChangedSince(change.Cluster.Resource(), change.checkedAt)
+	}
+}
+
 func (c *WaitingChanges) Complete() error {
+	c.Stop()
 	c.ui.NotifySection("waiting complete %s", c.stats())
 	return nil
 }
 
+func (c *WaitingChanges) Stop() {
+	c.watcher.Stop()
+}
+
 func (c *WaitingChanges) stats() string {
 	return fmt.Sprintf("[%d/%d done]", c.numWaited, c.numTotal)
 }
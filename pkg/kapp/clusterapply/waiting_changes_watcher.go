@@ -0,0 +1,107 @@
+package clusterapply
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+)
+
+// WaitingChangesWatcher keeps track of when resources (and their associated resources)
+// were last changed based on watch events so that waiting does not need to poll.
+type WaitingChangesWatcher struct {
+	identifiedResources ctlres.IdentifiedResources
+	ui                  UI
+
+	lock      sync.Mutex
+	changedAt map[string]time.Time // keyed by association label value
+	cancelCh  chan struct{}
+	watchErr  error
+
+	notifiedWatchErr bool
+}
+
+func NewWaitingChangesWatcher(identifiedResources ctlres.IdentifiedResources, ui UI) *WaitingChangesWatcher {
+	return &WaitingChangesWatcher{
+		identifiedResources: identifiedResources,
+		ui:                  ui,
+		changedAt:           map[string]time.Time{},
+	}
+}
+
+// Watch (re)starts watching given resources and their associated resources
+func (w *WaitingChangesWatcher) Watch(resources []ctlres.Resource) {
+	w.Stop()
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.watchErr != nil {
+		return // do not retry since watching is most likely not permitted
+	}
+
+	cancelCh := make(chan struct{})
+	resourcesCh := make(chan ctlres.Resource)
+
+	w.cancelCh = cancelCh
+
+	go func() {
+		err := w.identifiedResources.WatchAssociated(resources, resourcesCh, cancelCh)
+		if err != nil {
+			w.lock.Lock()
+			w.watchErr = err
+			w.lock.Unlock()
+		}
+	}()
+
+	go func() {
+		for {
+			select {
+			case res := <-resourcesCh:
+				assocLabel := ctlres.NewAssociationLabel(res)
+				w.lock.Lock()
+				w.changedAt[res.Labels()[assocLabel.Key()]] = time.Now()
+				w.lock.Unlock()
+
+			case <-cancelCh:
+				return
+			}
+		}
+	}()
+}
+
+// IsWatching returns false if watching failed and changes should be polled instead
+func (w *WaitingChangesWatcher) IsWatching() bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.watchErr != nil {
+		if !w.notifiedWatchErr {
+			w.ui.Notify([]string{fmt.Sprintf("Falling back to polling while waiting: %s", w.watchErr)})
+			w.notifiedWatchErr = true
+		}
+		return false
+	}
+
+	return w.cancelCh != nil
+}
+
+// ChangedSince returns true if resource or any of its associated resources changed after given time
+func (w *WaitingChangesWatcher) ChangedSince(res ctlres.Resource, t time.Time) bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	changedAt, found := w.changedAt[ctlres.NewAssociationLabel(res).Value()]
+	return found && changedAt.After(t)
+}
+
+func (w *WaitingChangesWatcher) Stop() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.cancelCh != nil {
+		close(w.cancelCh)
+		w.cancelCh = nil
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	ctlcap "github.com/k14s/kapp/pkg/kapp/clusterapply"
+	ctlresm "github.com/k14s/kapp/pkg/kapp/resourcesmisc"
 	"github.com/spf13/cobra"
 )
 
@@ -44,10 +45,28 @@ func (s *ApplyFlags) SetWithDefaults(prefix string, defaults ApplyFlags, cmd *co
 	cmd.Flags().BoolVar(&s.Wait, prefix+"wait", defaults.Wait, "Set to wait for changes to be applied")
 	cmd.Flags().BoolVar(&s.WaitIgnored, prefix+"wait-ignored", defaults.WaitIgnored, "Set to wait for ignored changes to be applied")
 
-	cmd.Flags().DurationVar(&s.WaitingChangesOpts.Timeout, prefix+"wait-timeout",
-		mustParseDuration("15m"), "Maximum amount of time to wait")
-	cmd.Flags().DurationVar(&s.WaitingChangesOpts.CheckInterval, prefix+"wait-check-interval",
+	setWaitingChangesOptsFlags(&s.WaitingChangesOpts, prefix, cmd)
+	setPodFailureOptsFlags(&s.AddOrUpdateChangeOpts.PodFailureOpts, prefix, cmd)
+}
+
+func setWaitingChangesOptsFlags(opts *ctlcap.WaitingChangesOpts, prefix string, cmd *cobra.Command) {
+	cmd.Flags().DurationVar(&opts.Timeout, prefix+"wait-timeout",
+		mustParseDuration("15m"), "Maximum amount of time to wait for each change (can be overridden per resource)")
+	cmd.Flags().DurationVar(&opts.CheckInterval, prefix+"wait-check-interval",
 		mustParseDuration("1s"), "Amount of time to sleep between checks while waiting")
+	cmd.Flags().DurationVar(&opts.ResyncInterval, prefix+"wait-resync-interval",
+		mustParseDuration("30s"), "Maximum amount of time between checks of changes that did not receive watch events")
+	cmd.Flags().Int64Var(&opts.FailureLogsLines, prefix+"wait-failure-logs-lines", 10,
+		"Number of log lines to show from failed Pods when waiting fails (0 disables)")
+}
+
+func setPodFailureOptsFlags(opts *ctlresm.PodFailureOpts, prefix string, cmd *cobra.Command) {
+	defaults := ctlresm.DefaultPodFailureOpts()
+
+	cmd.Flags().IntVar(&opts.RestartThreshold, prefix+"wait-pod-failure-restart-threshold", defaults.RestartThreshold,
+		"Minimum number of restarts of crash looping container to fail waiting (0 disables; can be overridden per Pod)")
+	cmd.Flags().DurationVar(&opts.ImagePullDuration, prefix+"wait-pod-failure-image-pull-duration", defaults.ImagePullDuration,
+		"Amount of time container image pulls are allowed to fail before failing waiting (0 disables; can be overridden per Pod)")
 }
 
 func mustParseDuration(str string) time.Duration {
@@ -46,7 +46,7 @@ func NewDeleteCmd(o *DeleteOptions, flagsFactory cmdcore.FlagsFactory) *cobra.Co
 }
 
 func (o *DeleteOptions) Run() error {
-	app, _, identifiedResources, err := AppFactory(o.depsFactory, o.AppFlags, o.ResourceTypesFlags, o.logger)
+	app, coreClient, identifiedResources, err := AppFactory(o.depsFactory, o.AppFlags, o.ResourceTypesFlags, o.logger)
 	if err != nil {
 		return err
 	}
@@ -87,6 +87,14 @@ func (o *DeleteOptions) Run() error {
 	}
 
 	existingResources = applicableExistingResources
+
+	// Only default and cluster config are used (e.g. for wait timeouts and
+	// explanations) since delete does not accept config files
+	conf, err := waitConf(coreClient, o.AppFlags.NamespaceFlags.Name, nil, cmdtools.ConfigFlags{})
+	if err != nil {
+		return err
+	}
+
 	changeFactory := ctldiff.NewChangeFactory(nil, nil)
 
 	o.changeIgnored(existingResources)
@@ -99,7 +107,7 @@ func (o *DeleteOptions) Run() error {
 	}
 
 	msgsUI := cmdcore.NewDedupingMessagesUI(cmdcore.NewPlainMessagesUI(o.ui))
-	clusterChangeFactory := ctlcap.NewClusterChangeFactory(o.ApplyFlags.ClusterChangeOpts, identifiedResources, changeFactory, changeSetFactory, msgsUI)
+	clusterChangeFactory := ctlcap.NewClusterChangeFactory(o.ApplyFlags.ClusterChangeOpts, identifiedResources, changeFactory, changeSetFactory, conf.WaitRules(), conf.OwnerReferenceAssociationRules(), msgsUI)
 	clusterChangeSet := ctlcap.NewClusterChangeSet(changes, o.ApplyFlags.ClusterChangeSetOpts, clusterChangeFactory, msgsUI)
 
 	clusterChanges, clusterChangesGraph, err := clusterChangeSet.Calculate()
@@ -107,6 +115,13 @@ func (o *DeleteOptions) Run() error {
 		return err
 	}
 
+	o.DiffFlags.ChangeSetViewOpts.WaitRules = conf.WaitRules()
+	o.DiffFlags.ChangeSetViewOpts.ExplainOpts = ctlcap.ResourceExplanationOpts{
+		Conf:                  conf,
+		DefaultUpdateStrategy: o.ApplyFlags.AddOrUpdateChangeOpts.DefaultUpdateStrategy,
+		WaitTimeout:           o.ApplyFlags.WaitingChangesOpts.Timeout,
+	}
+
 	ctlcap.NewChangeSetView(ctlcap.ClusterChangesAsChangeViews(clusterChanges), o.DiffFlags.ChangeSetViewOpts).Print(o.ui)
 
 	if o.DiffFlags.Run {
@@ -27,6 +27,8 @@ type DeployOptions struct {
 
 	AppFlags            AppFlags
 	FileFlags           cmdtools.FileFlags
+	ConfigFlags         cmdtools.ConfigFlags
+	OverrideFlags       cmdtools.OverrideFlags
 	DiffFlags           cmdtools.DiffFlags
 	ResourceFilterFlags cmdtools.ResourceFilterFlags
 	ApplyFlags          ApplyFlags
@@ -55,6 +57,14 @@ func NewDeployCmd(o *DeployOptions, flagsFactory cmdcore.FlagsFactory) *cobra.Co
   # Deploy app 'app1' while showing full text diff
   kapp deploy -a app1 -f config/ --diff-changes
 
+  # Deploy app 'app1' with kapp config kept separately from app resources
+  kapp deploy -a app1 -f config/ --config kapp-config.yml
+
+  # Deploy app 'app1' with pinned image and replicas
+  kapp deploy -a app1 -f config/ \
+    --image-override app=registry.io/app@sha256:... \
+    --set-replicas Deployment/app-ns/app=3
+
   # Deploy app 'app1' based on remote file
   kapp deploy -a app1 \
     -f https://github.com/...download/v0.6.0/crds.yaml \
@@ -65,6 +75,8 @@ func NewDeployCmd(o *DeployOptions, flagsFactory cmdcore.FlagsFactory) *cobra.Co
 
 	o.AppFlags.Set(cmd, flagsFactory)
 	o.FileFlags.Set(cmd)
+	o.ConfigFlags.Set(cmd)
+	o.OverrideFlags.Set(cmd)
 	o.DiffFlags.SetWithPrefix("diff", cmd)
 	o.ResourceFilterFlags.Set(cmd)
 	o.ApplyFlags.SetWithDefaults("", ApplyFlagsDeployDefaults, cmd)
@@ -86,6 +98,11 @@ func (o *DeployOptions) Run() error {
 		return err
 	}
 
+	overrideMods, err := o.OverrideFlags.Mods()
+	if err != nil {
+		return err
+	}
+
 	err = app.CreateOrUpdate(appLabels)
 	if err != nil {
 		return err
@@ -96,7 +113,36 @@ func (o *DeployOptions) Run() error {
 		return err
 	}
 
-	newResources, conf, err := ctlconf.NewConfFromResourcesWithDefaults(newResources)
+	clusterConfigRs, err := ctlconf.NewClusterConfigResources(coreClient, o.AppFlags.NamespaceFlags.Name)
+	if err != nil {
+		return err
+	}
+
+	confSrcs, err := o.ConfigFlags.ConfSources(clusterConfigRs)
+	if err != nil {
+		return err
+	}
+
+	newResources, conf, err := ctlconf.NewConfFromResourcesWithSources(newResources, confSrcs)
+	if err != nil {
+		return err
+	}
+
+	if conf.HasExternalWaitChecks() && !o.DeployFlags.AllowExternalWaitChecks {
+		return fmt.Errorf("Expected to find flag '--wait-allow-external-checks' " +
+			"since config specifies wait rules with external checks")
+	}
+
+	// Annotations are added before preparation since they
+	// may affect it (e.g. nonce and label scoping annotations)
+	err = o.applyMods(newResources, conf.AnnotationMods())
+	if err != nil {
+		return err
+	}
+
+	// Overrides are also applied before preparation so that they are
+	// reflected in diff (flags are applied last to win over config)
+	err = o.applyMods(newResources, append(conf.OverrideMods(), overrideMods...))
 	if err != nil {
 		return err
 	}
@@ -157,7 +203,25 @@ func (o *DeployOptions) Run() error {
 
 	existingResources = resourceFilter.Apply(existingResources)
 
-	changeFactory := ctldiff.NewChangeFactory(conf.RebaseMods(), conf.DiffAgainstLastAppliedFieldExclusionMods())
+	rebaseMods, err := conf.RebaseMods()
+	if err != nil {
+		return err
+	}
+
+	clusterHPAs, err := identifiedResources.ListOfTypes(ctldiff.HPAResourceTypes, o.namespaces(newResources))
+	if err != nil {
+		return err
+	}
+
+	hpaRebase, err := ctldiff.NewHPAReplicasRebase(newResources, existingResources, clusterHPAs)
+	if err != nil {
+		return err
+	}
+
+	// Config rebase rules are applied after so that they take precedence
+	rebaseMods = append(hpaRebase.Mods(), rebaseMods...)
+
+	changeFactory := ctldiff.NewChangeFactory(rebaseMods, conf.DiffAgainstLastAppliedFieldExclusionMods())
 	changeSetFactory := ctldiff.NewChangeSetFactory(o.DiffFlags.ChangeSetOpts, changeFactory)
 
 	changes, err := ctldiff.NewChangeSetWithTemplates(
@@ -168,7 +232,7 @@ func (o *DeployOptions) Run() error {
 	}
 
 	msgsUI := cmdcore.NewDedupingMessagesUI(cmdcore.NewPlainMessagesUI(o.ui))
-	clusterChangeFactory := ctlcap.NewClusterChangeFactory(o.ApplyFlags.ClusterChangeOpts, identifiedResources, changeFactory, changeSetFactory, msgsUI)
+	clusterChangeFactory := ctlcap.NewClusterChangeFactory(o.ApplyFlags.ClusterChangeOpts, identifiedResources, changeFactory, changeSetFactory, conf.WaitRules(), conf.OwnerReferenceAssociationRules(), msgsUI)
 	clusterChangeSet := ctlcap.NewClusterChangeSet(changes, o.ApplyFlags.ClusterChangeSetOpts, clusterChangeFactory, msgsUI)
 
 	clusterChanges, clusterChangesGraph, err := clusterChangeSet.Calculate()
@@ -176,6 +240,14 @@ func (o *DeployOptions) Run() error {
 		return err
 	}
 
+	o.DiffFlags.ChangeSetViewOpts.WaitRules = conf.WaitRules()
+	o.DiffFlags.ChangeSetViewOpts.HPAReplicasRebase = hpaRebase
+	o.DiffFlags.ChangeSetViewOpts.ExplainOpts = ctlcap.ResourceExplanationOpts{
+		Conf:                  conf,
+		DefaultUpdateStrategy: o.ApplyFlags.AddOrUpdateChangeOpts.DefaultUpdateStrategy,
+		WaitTimeout:           o.ApplyFlags.WaitingChangesOpts.Timeout,
+	}
+
 	changeSetView := ctlcap.NewChangeSetView(ctlcap.ClusterChangesAsChangeViews(clusterChanges), o.DiffFlags.ChangeSetViewOpts)
 	changeSetView.Print(o.ui)
 
@@ -241,13 +313,41 @@ func (o *DeployOptions) newResources() ([]ctlres.Resource, error) {
 	return allResources, nil
 }
 
+func (o *DeployOptions) applyMods(resources []ctlres.Resource, mods []ctlres.ResourceMod) error {
+	for _, res := range resources {
+		for _, mod := range mods {
+			err := mod.Apply(res)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+const (
+	clusterNsNamePlaceholder = "(cluster)"
+)
+
+// namespaces returns names of namespaces used by given resources
+// (cluster level resources are excluded)
+func (o *DeployOptions) namespaces(resources []ctlres.Resource) []string {
+	var names []string
+	for _, ns := range o.nsNames(resources) {
+		if ns != clusterNsNamePlaceholder {
+			names = append(names, ns)
+		}
+	}
+	return names
+}
+
 func (o *DeployOptions) nsNames(resources []ctlres.Resource) []string {
 	uniqNames := map[string]struct{}{}
 	names := []string{}
 	for _, res := range resources {
 		ns := res.Namespace()
 		if ns == "" {
-			ns = "(cluster)"
+			ns = clusterNsNamePlaceholder
 		}
 		if _, found := uniqNames[ns]; !found {
 			names = append(names, ns)
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	cmdcore "github.com/k14s/kapp/pkg/kapp/cmd/core"
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	"github.com/spf13/cobra"
+)
+
+type DeployConfigValidateOptions struct {
+	ui          ui.UI
+	depsFactory cmdcore.DepsFactory
+
+	Files []string
+}
+
+func NewDeployConfigValidateOptions(ui ui.UI, depsFactory cmdcore.DepsFactory) *DeployConfigValidateOptions {
+	return &DeployConfigValidateOptions{ui: ui, depsFactory: depsFactory}
+}
+
+func NewDeployConfigValidateCmd(o *DeployConfigValidateOptions, flagsFactory cmdcore.FlagsFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate deploy config",
+		RunE:  func(_ *cobra.Command, _ []string) error { return o.Run() },
+	}
+	cmd.Flags().StringSliceVarP(&o.Files, "file", "f", nil, "Set file (format: /tmp/foo, https://..., -) (can repeat)")
+	return cmd
+}
+
+func (o *DeployConfigValidateOptions) Run() error {
+	if len(o.Files) == 0 {
+		return fmt.Errorf("Expected at least one file to be specified")
+	}
+
+	var numConfigs int
+	var errs []string
+
+	for _, file := range o.Files {
+		fileRs, err := ctlres.NewFileResources(file)
+		if err != nil {
+			return err
+		}
+
+		for _, fileRes := range fileRs {
+			resources, err := fileRes.Resources()
+			if err != nil {
+				return err
+			}
+
+			for _, res := range resources {
+				if !ctlconf.IsConfigResource(res) {
+					continue
+				}
+
+				numConfigs++
+
+				_, err := ctlconf.NewConfigFromConfigResource(res)
+				if err != nil {
+					errs = append(errs, err.Error())
+				}
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("Found %d invalid config(s) out of %d:\n\n%s",
+			len(errs), numConfigs, strings.Join(errs, "\n\n"))
+	}
+
+	o.ui.PrintLinef("Validated %d config(s)", numConfigs)
+
+	return nil
+}
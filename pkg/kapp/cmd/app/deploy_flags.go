@@ -16,6 +16,8 @@ type DeployFlags struct {
 
 	AppChangesMaxToKeep int
 
+	AllowExternalWaitChecks bool
+
 	Logs    bool
 	LogsAll bool
 }
@@ -37,6 +39,9 @@ func (s *DeployFlags) Set(cmd *cobra.Command) {
 
 	cmd.Flags().IntVar(&s.AppChangesMaxToKeep, "app-changes-max-to-keep", ctlapp.AppChangesMaxToKeepDefault, "Maximum number of app changes to keep")
 
+	cmd.Flags().BoolVar(&s.AllowExternalWaitChecks, "wait-allow-external-checks", false,
+		"Allow running executables specified in config wait rules")
+
 	cmd.Flags().BoolVar(&s.Logs, "logs", true, fmt.Sprintf("Show logs from Pods annotated as '%s'", deployLogsAnnKey))
 	cmd.Flags().BoolVar(&s.LogsAll, "logs-all", false, "Show logs from all Pods")
 }
@@ -0,0 +1,334 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	uitable "github.com/cppforlife/go-cli-ui/ui/table"
+	ctlcap "github.com/k14s/kapp/pkg/kapp/clusterapply"
+	cmdcore "github.com/k14s/kapp/pkg/kapp/cmd/core"
+	cmdtools "github.com/k14s/kapp/pkg/kapp/cmd/tools"
+	"github.com/k14s/kapp/pkg/kapp/logger"
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type EventsOptions struct {
+	ui          ui.UI
+	depsFactory cmdcore.DepsFactory
+	logger      logger.Logger
+
+	AppFlags            AppFlags
+	ResourceFilterFlags cmdtools.ResourceFilterFlags
+	ResourceTypesFlags  ResourceTypesFlags
+
+	Follow bool
+}
+
+func NewEventsOptions(ui ui.UI, depsFactory cmdcore.DepsFactory, logger logger.Logger) *EventsOptions {
+	return &EventsOptions{ui: ui, depsFactory: depsFactory, logger: logger}
+}
+
+func NewEventsCmd(o *EventsOptions, flagsFactory cmdcore.FlagsFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "events",
+		Aliases: []string{"ev"},
+		Short:   "List events of app's resources",
+		RunE:    func(_ *cobra.Command, _ []string) error { return o.Run() },
+		Annotations: map[string]string{
+			cmdcore.AppHelpGroup.Key: cmdcore.AppHelpGroup.Value,
+		},
+	}
+	o.AppFlags.Set(cmd, flagsFactory)
+	o.ResourceFilterFlags.Set(cmd)
+	o.ResourceTypesFlags.Set(cmd)
+	cmd.Flags().BoolVarP(&o.Follow, "follow", "f", false, "As new events are created, continue to show them")
+	return cmd
+}
+
+func (o *EventsOptions) Run() error {
+	app, _, identifiedResources, err := AppFactory(o.depsFactory, o.AppFlags, o.ResourceTypesFlags, o.logger)
+	if err != nil {
+		return err
+	}
+
+	labelSelector, err := app.LabelSelector()
+	if err != nil {
+		return err
+	}
+
+	resourceFilter, err := o.ResourceFilterFlags.ResourceFilter()
+	if err != nil {
+		return err
+	}
+
+	resources, err := identifiedResources.List(labelSelector)
+	if err != nil {
+		return err
+	}
+
+	assocResources, assocSelector, err := o.associatedResources(identifiedResources, resources)
+	if err != nil {
+		return err
+	}
+
+	resources = append(resources, assocResources...)
+
+	matcher := newAppEventsMatcher(identifiedResources, resources,
+		resourceFilter, []labels.Selector{labelSelector, assocSelector})
+
+	var resourceVersions []eventsResourceVersion
+	var events []corev1.Event
+
+	for _, ns := range o.namespaces(resources) {
+		nsEvents, resourceVersion, err := identifiedResources.Events(ns)
+		if err != nil {
+			return err
+		}
+
+		resourceVersions = append(resourceVersions, eventsResourceVersion{ns, resourceVersion})
+
+		for _, event := range nsEvents {
+			if matcher.MatchesKnown(event) {
+				events = append(events, event)
+			}
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return ctlres.EventLastSeen(events[i]).Before(ctlres.EventLastSeen(events[j]))
+	})
+
+	EventsView{Source: fmt.Sprintf("app '%s'", app.Name()), Events: events}.Print(o.ui)
+
+	if !o.Follow {
+		return nil
+	}
+
+	return o.follow(identifiedResources, resourceVersions, matcher)
+}
+
+// associatedResources returns resources that are associated with app resources
+// via association label (they may not carry app label) and selector for them
+func (o *EventsOptions) associatedResources(identifiedResources ctlres.IdentifiedResources,
+	resources []ctlres.Resource) ([]ctlres.Resource, labels.Selector, error) {
+
+	var parentResources []ctlres.Resource
+
+	for _, res := range resources {
+		if !res.Transient() {
+			parentResources = append(parentResources, res)
+		}
+	}
+
+	if len(parentResources) == 0 {
+		return nil, labels.Nothing(), nil
+	}
+
+	assocSelector, err := ctlres.NewAssociationLabelsSelector(parentResources)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	assocResources, err := identifiedResources.List(assocSelector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return assocResources, assocSelector, nil
+}
+
+// namespaces returns namespaces that may contain events for given resources
+func (o *EventsOptions) namespaces(resources []ctlres.Resource) []string {
+	uniqNames := map[string]struct{}{}
+	names := []string{}
+
+	for _, res := range resources {
+		ns := res.Namespace()
+		if ns == "" {
+			ns = "default" // events for cluster level resources
+		}
+		if _, found := uniqNames[ns]; !found {
+			names = append(names, ns)
+			uniqNames[ns] = struct{}{}
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+type eventsResourceVersion struct {
+	Namespace       string
+	ResourceVersion string
+}
+
+func (o *EventsOptions) follow(identifiedResources ctlres.IdentifiedResources,
+	resourceVersions []eventsResourceVersion, matcher *appEventsMatcher) error {
+
+	cancelCh := make(chan struct{})
+	cmdcore.CancelSignals{}.Watch(func() { close(cancelCh) })
+
+	eventsCh := make(chan corev1.Event)
+	errsCh := make(chan error, len(resourceVersions))
+
+	for _, rv := range resourceVersions {
+		rv := rv // copy
+
+		go func() {
+			errsCh <- identifiedResources.WatchEvents(rv.Namespace, rv.ResourceVersion, eventsCh, cancelCh)
+		}()
+	}
+
+	var numDone int
+
+	for numDone < len(resourceVersions) {
+		select {
+		case event := <-eventsCh:
+			if matcher.Matches(event) {
+				o.ui.PrintLinef("%s", EventsView{}.Line(event))
+			}
+
+		case err := <-errsCh:
+			if err != nil {
+				return err
+			}
+			numDone++
+		}
+	}
+
+	return nil
+}
+
+// appEventsMatcher determines if event's involved object belongs to an app.
+// Since app may gain new resources (e.g. Pods) while following events,
+// unknown objects are fetched and checked against app selectors.
+type appEventsMatcher struct {
+	identifiedResources ctlres.IdentifiedResources
+	resourceFilter      ctlres.ResourceFilter
+	selectors           []labels.Selector
+
+	matchedUIDs map[string]bool
+}
+
+func newAppEventsMatcher(identifiedResources ctlres.IdentifiedResources, resources []ctlres.Resource,
+	resourceFilter ctlres.ResourceFilter, selectors []labels.Selector) *appEventsMatcher {
+
+	matchedUIDs := map[string]bool{}
+
+	for _, res := range resources {
+		matchedUIDs[res.UID()] = resourceFilter.Matches(res)
+	}
+
+	return &appEventsMatcher{identifiedResources, resourceFilter, selectors, matchedUIDs}
+}
+
+// MatchesKnown only checks already known objects
+// (avoids fetching objects of unrelated events)
+func (m *appEventsMatcher) MatchesKnown(event corev1.Event) bool {
+	return m.matchedUIDs[string(event.InvolvedObject.UID)]
+}
+
+func (m *appEventsMatcher) Matches(event corev1.Event) bool {
+	uid := string(event.InvolvedObject.UID)
+
+	if matched, found := m.matchedUIDs[uid]; found {
+		return matched
+	}
+
+	obj := event.InvolvedObject
+
+	res, err := ctlcap.NewIdentifiedRelatedResources(m.identifiedResources).Get(
+		obj.APIVersion, obj.Kind, obj.Namespace, obj.Name)
+	if err != nil {
+		// Object may have been already deleted; do not
+		// remember outcome as object may be created later
+		return false
+	}
+
+	var matched bool
+
+	for _, sel := range m.selectors {
+		if sel.Matches(labels.Set(res.Labels())) {
+			matched = m.resourceFilter.Matches(res)
+			break
+		}
+	}
+
+	m.matchedUIDs[uid] = matched
+
+	return matched
+}
+
+type EventsView struct {
+	Source string
+	Events []corev1.Event
+}
+
+func (v EventsView) Print(ui ui.UI) {
+	table := uitable.Table{
+		Title:   fmt.Sprintf("Events for %s", v.Source),
+		Content: "events",
+
+		Header: []uitable.Header{
+			uitable.NewHeader("Namespace"),
+			uitable.NewHeader("Type"),
+			uitable.NewHeader("Reason"),
+			uitable.NewHeader("Object"),
+			uitable.NewHeader("Count"),
+			uitable.NewHeader("Age"),
+			uitable.NewHeader("Message"),
+		},
+
+		// Events are ordered by time they were last seen
+		FillFirstColumn: true,
+	}
+
+	for _, event := range v.Events {
+		table.Rows = append(table.Rows, []uitable.Value{
+			cmdcore.NewValueNamespace(event.InvolvedObject.Namespace),
+			uitable.ValueFmt{
+				V:     uitable.NewValueString(event.Type),
+				Error: event.Type == corev1.EventTypeWarning,
+			},
+			uitable.NewValueString(event.Reason),
+			uitable.NewValueString(v.object(event)),
+			uitable.NewValueInt(int(v.count(event))),
+			cmdcore.NewValueAge(ctlres.EventLastSeen(event)),
+			uitable.NewValueString(wordwrap.WrapString(event.Message, 60)),
+		})
+	}
+
+	ui.PrintTable(table)
+}
+
+// Line formats event for streaming (e.g. while following events)
+func (v EventsView) Line(event corev1.Event) string {
+	ns := event.InvolvedObject.Namespace
+	if len(ns) == 0 {
+		ns = "(cluster)"
+	}
+
+	return fmt.Sprintf("%s %s %s %s %s (x%d): %s",
+		ctlres.EventLastSeen(event).Format("3:04:05PM"), event.Type, event.Reason,
+		ns, v.object(event), v.count(event), event.Message)
+}
+
+func (EventsView) object(event corev1.Event) string {
+	return fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name)
+}
+
+func (EventsView) count(event corev1.Event) int32 {
+	switch {
+	case event.Series != nil:
+		return event.Series.Count
+	case event.Count > 0:
+		return event.Count
+	default:
+		return 1
+	}
+}
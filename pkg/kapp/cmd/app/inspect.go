@@ -2,12 +2,16 @@ package app
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/cppforlife/go-cli-ui/ui"
+	ctlcap "github.com/k14s/kapp/pkg/kapp/clusterapply"
 	cmdcore "github.com/k14s/kapp/pkg/kapp/cmd/core"
 	cmdtools "github.com/k14s/kapp/pkg/kapp/cmd/tools"
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
 	ctldiff "github.com/k14s/kapp/pkg/kapp/diff"
 	"github.com/k14s/kapp/pkg/kapp/logger"
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
 	"github.com/spf13/cobra"
 )
 
@@ -19,10 +23,16 @@ type InspectOptions struct {
 	AppFlags            AppFlags
 	ResourceFilterFlags cmdtools.ResourceFilterFlags
 	ResourceTypesFlags  ResourceTypesFlags
+	ConfigFlags         cmdtools.ConfigFlags
 
-	Raw    bool
-	Status bool
-	Tree   bool
+	Raw          bool
+	Status       bool
+	Tree         bool
+	TreeMaxDepth int
+
+	Watch                bool
+	WatchRefreshInterval time.Duration
+	WatchResyncInterval  time.Duration
 }
 
 func NewInspectOptions(ui ui.UI, depsFactory cmdcore.DepsFactory, logger logger.Logger) *InspectOptions {
@@ -42,14 +52,28 @@ func NewInspectCmd(o *InspectOptions, flagsFactory cmdcore.FlagsFactory) *cobra.
 	o.AppFlags.Set(cmd, flagsFactory)
 	o.ResourceFilterFlags.Set(cmd)
 	o.ResourceTypesFlags.Set(cmd)
+	o.ConfigFlags.Set(cmd)
 	cmd.Flags().BoolVar(&o.Raw, "raw", false, "Output raw YAML resource content")
 	cmd.Flags().BoolVar(&o.Status, "status", false, "Output status content")
 	cmd.Flags().BoolVarP(&o.Tree, "tree", "t", false, "Tree view")
+	cmd.Flags().IntVar(&o.TreeMaxDepth, "tree-owner-refs-depth", 0,
+		"Maximum depth of resources found via ownerReferences (that are not labeled as part of the app) in tree view (0 disables; enabling it lists resources of all types in the cluster)")
+	cmd.Flags().BoolVar(&o.Watch, "watch", false, "Keep refreshing view as resources change (until interrupted)")
+	cmd.Flags().DurationVar(&o.WatchRefreshInterval, "watch-refresh-interval",
+		mustParseDuration("1s"), "Minimum amount of time between view refreshes while watching")
+	cmd.Flags().DurationVar(&o.WatchResyncInterval, "watch-resync-interval",
+		mustParseDuration("1m"), "Amount of time between full lists of app resources while watching (to find resources of new types)")
 	return cmd
 }
 
 func (o *InspectOptions) Run() error {
-	app, _, identifiedResources, err := AppFactory(o.depsFactory, o.AppFlags, o.ResourceTypesFlags, o.logger)
+	app, coreClient, identifiedResources, err := AppFactory(o.depsFactory, o.AppFlags, o.ResourceTypesFlags, o.logger)
+	if err != nil {
+		return err
+	}
+
+	// Health is determined the same way as during waiting
+	conf, err := waitConf(coreClient, o.AppFlags.NamespaceFlags.Name, nil, o.ConfigFlags)
 	if err != nil {
 		return err
 	}
@@ -59,18 +83,50 @@ func (o *InspectOptions) Run() error {
 		return err
 	}
 
+	resourceFilter, err := o.ResourceFilterFlags.ResourceFilter()
+	if err != nil {
+		return err
+	}
+
+	if o.Watch {
+		if o.Raw {
+			return fmt.Errorf("Expected flag '--watch' to not be used together with flag '--raw'")
+		}
+		return InspectWatch{app.Name(), identifiedResources, labelSelector, conf.WaitRules(), o}.Run(resourceFilter)
+	}
+
 	resources, err := identifiedResources.List(labelSelector)
 	if err != nil {
 		return err
 	}
 
-	resourceFilter, err := o.ResourceFilterFlags.ResourceFilter()
+	ownedResources, err := o.ownedResources(identifiedResources, resources)
 	if err != nil {
 		return err
 	}
 
-	resources = resourceFilter.Apply(resources)
-	source := fmt.Sprintf("app '%s'", app.Name())
+	return o.print(app.Name(), conf.WaitRules(), resources, ownedResources, resourceFilter, nil)
+}
+
+// ownedResources returns resources created by controllers for app
+// resources that are not labeled (e.g. due to lack of label propagation)
+func (o *InspectOptions) ownedResources(identifiedResources ctlres.IdentifiedResources,
+	resources []ctlres.Resource) ([]ctlres.Resource, error) {
+
+	if !o.Tree || o.TreeMaxDepth <= 0 {
+		return nil, nil
+	}
+	return identifiedResources.ListOwned(resources, o.TreeMaxDepth)
+}
+
+func (o *InspectOptions) print(appName string, waitRules []ctlconf.WaitRule, resources, ownedResources []ctlres.Resource,
+	resourceFilter ctlres.ResourceFilter, changedUIDs map[string]struct{}) error {
+
+	// Health is based on all app resources regardless of filters
+	health := ctlcap.NewAppHealth(resources, waitRules)
+
+	resources = resourceFilter.Apply(append(resources, ownedResources...))
+	source := fmt.Sprintf("app '%s'", appName)
 
 	switch {
 	case o.Raw:
@@ -89,13 +145,15 @@ func (o *InspectOptions) Run() error {
 		}
 
 	case o.Status:
-		InspectStatusView{Source: source, Resources: resources}.Print(o.ui)
+		InspectStatusView{Source: source, Resources: resources, ChangedUIDs: changedUIDs}.Print(o.ui)
 
 	default:
+		o.ui.PrintLinef("App health: %s (%s)", health.State(), health.CountsString())
+
 		if o.Tree {
-			cmdtools.InspectTreeView{Source: source, Resources: resources, Sort: true}.Print(o.ui)
+			cmdtools.InspectTreeView{Source: source, Resources: resources, Sort: true, WaitRules: waitRules, ChangedUIDs: changedUIDs}.Print(o.ui)
 		} else {
-			cmdtools.InspectView{Source: source, Resources: resources, Sort: true}.Print(o.ui)
+			cmdtools.InspectView{Source: source, Resources: resources, Sort: true, WaitRules: waitRules, ChangedUIDs: changedUIDs}.Print(o.ui)
 		}
 	}
 
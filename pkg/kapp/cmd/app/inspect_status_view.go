@@ -6,12 +6,15 @@ import (
 	"github.com/cppforlife/go-cli-ui/ui"
 	uitable "github.com/cppforlife/go-cli-ui/ui/table"
 	cmdcore "github.com/k14s/kapp/pkg/kapp/cmd/core"
+	cmdtools "github.com/k14s/kapp/pkg/kapp/cmd/tools"
 	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
 )
 
 type InspectStatusView struct {
 	Source    string
 	Resources []ctlres.Resource
+
+	ChangedUIDs map[string]struct{} // highlighted (e.g. while watching)
 }
 
 func (v InspectStatusView) Print(ui ui.UI) {
@@ -44,7 +47,7 @@ func (v InspectStatusView) Print(ui ui.UI) {
 	for _, resource := range v.Resources {
 		table.Rows = append(table.Rows, []uitable.Value{
 			cmdcore.NewValueNamespace(resource.Namespace()),
-			uitable.NewValueString(resource.Name()),
+			cmdtools.NewValueResourceName(resource, v.ChangedUIDs),
 			uitable.NewValueString(resource.Kind()),
 			uitable.NewValueString(resource.APIVersion()),
 			uitable.NewValueInterface(resource.Status()),
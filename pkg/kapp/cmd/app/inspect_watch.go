@@ -0,0 +1,158 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	ctlcap "github.com/k14s/kapp/pkg/kapp/clusterapply"
+	cmdcore "github.com/k14s/kapp/pkg/kapp/cmd/core"
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// InspectWatch keeps printing app resources as they change.
+// Instead of repeatedly listing all resource types, it relies
+// on watch events and only occasionally lists app resources.
+type InspectWatch struct {
+	appName             string
+	identifiedResources ctlres.IdentifiedResources
+	labelSelector       labels.Selector
+	waitRules           []ctlconf.WaitRule
+	opts                *InspectOptions
+}
+
+func (w InspectWatch) Run(resourceFilter ctlres.ResourceFilter) error {
+	cancelCh := make(chan struct{})
+	cmdcore.CancelSignals{}.Watch(func() { close(cancelCh) })
+
+	var prevStates map[string]string
+
+	for {
+		resources, err := w.identifiedResources.List(w.labelSelector)
+		if err != nil {
+			return err
+		}
+
+		// Owned resources are not labeled, hence they are only updated during resync
+		ownedResources, err := w.opts.ownedResources(w.identifiedResources, resources)
+		if err != nil {
+			return err
+		}
+
+		var canceled bool
+
+		prevStates, canceled, err = w.watchUntilResync(resources, ownedResources, resourceFilter, prevStates, cancelCh)
+		if err != nil || canceled {
+			return err
+		}
+	}
+}
+
+func (w InspectWatch) watchUntilResync(resources, ownedResources []ctlres.Resource,
+	resourceFilter ctlres.ResourceFilter, prevStates map[string]string,
+	cancelCh chan struct{}) (map[string]string, bool, error) {
+
+	rsByUID := map[string]ctlres.Resource{}
+
+	for _, res := range resources {
+		rsByUID[res.UID()] = res
+	}
+
+	eventsCh := make(chan ctlres.ResourceEvent)
+	watchCancelCh := make(chan struct{})
+	watchErrCh := make(chan error, 1)
+
+	defer close(watchCancelCh)
+
+	go func() {
+		watchErrCh <- w.identifiedResources.WatchLabeled(w.labelSelector, resources, eventsCh, watchCancelCh)
+	}()
+
+	resyncTimer := time.NewTimer(w.opts.WatchResyncInterval)
+	defer resyncTimer.Stop()
+
+	refreshTicker := time.NewTicker(w.opts.WatchRefreshInterval)
+	defer refreshTicker.Stop()
+
+	prevStates, err := w.print(rsByUID, ownedResources, resourceFilter, prevStates)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var needsRefresh bool
+
+	for {
+		select {
+		case event := <-eventsCh:
+			if event.Type == watch.Deleted {
+				delete(rsByUID, event.Resource.UID())
+			} else {
+				rsByUID[event.Resource.UID()] = event.Resource
+			}
+			needsRefresh = true
+
+		case <-refreshTicker.C:
+			if needsRefresh {
+				prevStates, err = w.print(rsByUID, ownedResources, resourceFilter, prevStates)
+				if err != nil {
+					return nil, false, err
+				}
+				needsRefresh = false
+			}
+
+		case err := <-watchErrCh:
+			if err != nil {
+				return nil, false, fmt.Errorf("Watching app resources: %s", err)
+			}
+
+		case <-resyncTimer.C:
+			return prevStates, false, nil
+
+		case <-cancelCh:
+			return prevStates, true, nil
+		}
+	}
+}
+
+func (w InspectWatch) print(rsByUID map[string]ctlres.Resource, ownedResources []ctlres.Resource,
+	resourceFilter ctlres.ResourceFilter, prevStates map[string]string) (map[string]string, error) {
+
+	var resources []ctlres.Resource
+
+	for _, res := range rsByUID {
+		resources = append(resources, res)
+	}
+
+	states := map[string]string{}
+	changedUIDs := map[string]struct{}{}
+
+	for _, res := range append(append([]ctlres.Resource{}, resources...), ownedResources...) {
+		uid := res.UID()
+		states[uid] = w.state(res)
+
+		// Do not highlight everything on first refresh
+		if prevStates != nil && prevStates[uid] != states[uid] {
+			changedUIDs[uid] = struct{}{}
+		}
+	}
+
+	w.opts.ui.PrintLinef("Refreshed at %s (%d changed)", time.Now().Format(time.RFC3339), len(changedUIDs))
+
+	return states, w.opts.print(w.appName, w.waitRules, resources, ownedResources, resourceFilter, changedUIDs)
+}
+
+// state includes information that is shown for each resource
+// so that only meaningful changes are highlighted
+func (w InspectWatch) state(res ctlres.Resource) string {
+	convergedRes := ctlcap.NewConvergedResource(res, nil, ctlcap.ConvergedResourceOpts{WaitRules: w.waitRules})
+	doneState, _, err := convergedRes.IsDoneApplying()
+	stateUI := ctlcap.NewDoneApplyStateUI(doneState, err)
+
+	// Status is only used for comparison, hence ignore errors
+	statusBs, _ := json.Marshal(res.Status())
+
+	return fmt.Sprintf("%s/%s/%s", stateUI.State, stateUI.Message, statusBs)
+}
@@ -7,7 +7,10 @@ import (
 
 	"github.com/cppforlife/go-cli-ui/ui"
 	uitable "github.com/cppforlife/go-cli-ui/ui/table"
+	ctlcap "github.com/k14s/kapp/pkg/kapp/clusterapply"
 	cmdcore "github.com/k14s/kapp/pkg/kapp/cmd/core"
+	cmdtools "github.com/k14s/kapp/pkg/kapp/cmd/tools"
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
 	"github.com/k14s/kapp/pkg/kapp/logger"
 	"github.com/spf13/cobra"
 )
@@ -19,6 +22,10 @@ type ListOptions struct {
 
 	NamespaceFlags cmdcore.NamespaceFlags
 	AllNamespaces  bool
+
+	Health       bool
+	FilterHealth []string
+	ConfigFlags  cmdtools.ConfigFlags
 }
 
 func NewListOptions(ui ui.UI, depsFactory cmdcore.DepsFactory, logger logger.Logger) *ListOptions {
@@ -37,6 +44,10 @@ func NewListCmd(o *ListOptions, flagsFactory cmdcore.FlagsFactory) *cobra.Comman
 	}
 	o.NamespaceFlags.Set(cmd, flagsFactory)
 	cmd.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", false, "List apps in all namespaces")
+	cmd.Flags().BoolVar(&o.Health, "health", false, "Show app health based on state of app resources")
+	cmd.Flags().StringSliceVar(&o.FilterHealth, "filter-health", nil,
+		"Set app health filter (example: degraded, progressing) (implies --health) (can repeat)")
+	o.ConfigFlags.Set(cmd)
 	return cmd
 }
 
@@ -51,7 +62,12 @@ func (o *ListOptions) Run() error {
 		nsHeader.Hidden = false
 	}
 
-	apps, _, _, err := AppFactoryClients(o.depsFactory, o.NamespaceFlags, ResourceTypesFlags{}, o.logger)
+	healthFilter, err := o.healthFilter()
+	if err != nil {
+		return err
+	}
+
+	apps, coreClient, identifiedResources, err := AppFactoryClients(o.depsFactory, o.NamespaceFlags, ResourceTypesFlags{}, o.logger)
 	if err != nil {
 		return err
 	}
@@ -67,6 +83,12 @@ func (o *ListOptions) Run() error {
 	lcaHeader := uitable.NewHeader("Last Change Age")
 	lcaHeader.Title = "Lca"
 
+	healthHeader := uitable.NewHeader("Health")
+	healthHeader.Hidden = !o.Health
+
+	healthCountsHeader := uitable.NewHeader("Health counts")
+	healthCountsHeader.Hidden = !o.Health
+
 	table := uitable.Table{
 		Title:   tableTitle,
 		Content: "apps",
@@ -78,6 +100,8 @@ func (o *ListOptions) Run() error {
 			uitable.NewHeader("Namespaces"),
 			lcsHeader,
 			lcaHeader,
+			healthHeader,
+			healthCountsHeader,
 		},
 
 		SortBy: []uitable.ColumnSort{
@@ -91,6 +115,8 @@ func (o *ListOptions) Run() error {
 		},
 	}
 
+	confByNs := map[string]ctlconf.Conf{}
+
 	for _, item := range items {
 		sel, err := item.LabelSelector()
 		if err != nil {
@@ -125,6 +151,42 @@ func (o *ListOptions) Run() error {
 			)
 		}
 
+		if o.Health {
+			resources, err := identifiedResources.List(sel)
+			if err != nil {
+				return err
+			}
+
+			// Health is determined the same way as during waiting
+			// (apps in different namespaces may use different cluster config)
+			conf, found := confByNs[item.Namespace()]
+			if !found {
+				conf, err = waitConf(coreClient, item.Namespace(), nil, o.ConfigFlags)
+				if err != nil {
+					return err
+				}
+				confByNs[item.Namespace()] = conf
+			}
+
+			health := ctlcap.NewAppHealth(resources, conf.WaitRules())
+
+			if len(healthFilter) > 0 {
+				if _, found := healthFilter[health.State()]; !found {
+					continue
+				}
+			}
+
+			row = append(row,
+				uitable.ValueFmt{
+					V:     uitable.NewValueString(string(health.State())),
+					Error: health.State() != ctlcap.AppHealthStateHealthy,
+				},
+				uitable.NewValueString(health.CountsString()),
+			)
+		} else {
+			row = append(row, uitable.NewValueString(""), uitable.NewValueString(""))
+		}
+
 		table.Rows = append(table.Rows, row)
 	}
 
@@ -132,3 +194,21 @@ func (o *ListOptions) Run() error {
 
 	return nil
 }
+
+func (o *ListOptions) healthFilter() (map[ctlcap.AppHealthState]struct{}, error) {
+	result := map[ctlcap.AppHealthState]struct{}{}
+
+	for _, val := range o.FilterHealth {
+		state, err := ctlcap.ParseAppHealthState(val)
+		if err != nil {
+			return nil, err
+		}
+		result[state] = struct{}{}
+	}
+
+	if len(result) > 0 {
+		o.Health = true
+	}
+
+	return result, nil
+}
@@ -0,0 +1,170 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	ctlcap "github.com/k14s/kapp/pkg/kapp/clusterapply"
+	cmdcore "github.com/k14s/kapp/pkg/kapp/cmd/core"
+	cmdtools "github.com/k14s/kapp/pkg/kapp/cmd/tools"
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
+	ctldiff "github.com/k14s/kapp/pkg/kapp/diff"
+	"github.com/k14s/kapp/pkg/kapp/logger"
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	ctlresm "github.com/k14s/kapp/pkg/kapp/resourcesmisc"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+type WaitOptions struct {
+	ui          ui.UI
+	depsFactory cmdcore.DepsFactory
+	logger      logger.Logger
+
+	AppFlags            AppFlags
+	ResourceFilterFlags cmdtools.ResourceFilterFlags
+	ResourceTypesFlags  ResourceTypesFlags
+	WaitingChangesOpts  ctlcap.WaitingChangesOpts
+	PodFailureOpts      ctlresm.PodFailureOpts
+
+	ConfigFiles             []string
+	ConfigFlags             cmdtools.ConfigFlags
+	AllowExternalWaitChecks bool
+}
+
+func NewWaitOptions(ui ui.UI, depsFactory cmdcore.DepsFactory, logger logger.Logger) *WaitOptions {
+	return &WaitOptions{ui: ui, depsFactory: depsFactory, logger: logger}
+}
+
+func NewWaitCmd(o *WaitOptions, flagsFactory cmdcore.FlagsFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wait",
+		Short: "Wait for app resources to converge (without applying changes)",
+		RunE:  func(_ *cobra.Command, _ []string) error { return o.Run() },
+		Annotations: map[string]string{
+			cmdcore.AppHelpGroup.Key: cmdcore.AppHelpGroup.Value,
+		},
+		Example: `
+  # Wait for all resources of app 'app1' to converge
+  kapp wait -a app1
+
+  # Wait using custom wait rules from kapp config in config/
+  kapp wait -a app1 -f config/`,
+	}
+	o.AppFlags.Set(cmd, flagsFactory)
+	o.ResourceFilterFlags.Set(cmd)
+	o.ResourceTypesFlags.Set(cmd)
+	setWaitingChangesOptsFlags(&o.WaitingChangesOpts, "", cmd)
+	setPodFailureOptsFlags(&o.PodFailureOpts, "", cmd)
+	cmd.Flags().StringSliceVarP(&o.ConfigFiles, "file", "f", nil,
+		"Set file with kapp config (format: /tmp/foo, https://..., -) (can repeat)")
+	o.ConfigFlags.Set(cmd)
+	cmd.Flags().BoolVar(&o.AllowExternalWaitChecks, "wait-allow-external-checks", false,
+		"Allow running executables specified in config wait rules")
+	return cmd
+}
+
+func (o *WaitOptions) Run() error {
+	app, coreClient, identifiedResources, err := AppFactory(o.depsFactory, o.AppFlags, o.ResourceTypesFlags, o.logger)
+	if err != nil {
+		return err
+	}
+
+	conf, err := o.conf(coreClient)
+	if err != nil {
+		return err
+	}
+
+	labelSelector, err := app.LabelSelector()
+	if err != nil {
+		return err
+	}
+
+	resourceFilter, err := o.ResourceFilterFlags.ResourceFilter()
+	if err != nil {
+		return err
+	}
+
+	resources, err := identifiedResources.List(labelSelector)
+	if err != nil {
+		return err
+	}
+
+	resources = resourceFilter.Apply(resources)
+
+	msgsUI := cmdcore.NewDedupingMessagesUI(cmdcore.NewPlainMessagesUI(o.ui))
+	changeFactory := ctldiff.NewChangeFactory(nil, nil)
+	changeSetFactory := ctldiff.NewChangeSetFactory(ctldiff.ChangeSetOpts{}, changeFactory)
+	clusterChangeOpts := ctlcap.ClusterChangeOpts{
+		Wait:                  true,
+		AddOrUpdateChangeOpts: ctlcap.AddOrUpdateChangeOpts{PodFailureOpts: o.PodFailureOpts},
+	}
+	clusterChangeFactory := ctlcap.NewClusterChangeFactory(clusterChangeOpts, identifiedResources,
+		changeFactory, changeSetFactory, conf.WaitRules(), conf.OwnerReferenceAssociationRules(), msgsUI)
+
+	var changes []ctlcap.WaitingChange
+
+	for _, res := range resources {
+		// Transient resources (e.g. Pods created by ReplicaSets) are
+		// accounted for via waiting on their associated parent resources
+		if res.Transient() {
+			continue
+		}
+
+		// Compare resource against itself since nothing is going to be applied
+		clusterChange := clusterChangeFactory.NewClusterChange(ctldiff.NewChange(res, res, res))
+		clusterChange.MarkNeedsWaiting()
+
+		if clusterChange.WaitOp() == ctlcap.ClusterChangeWaitOpNoop {
+			continue
+		}
+
+		changes = append(changes, ctlcap.WaitingChange{Cluster: clusterChange})
+	}
+
+	waitingChanges := ctlcap.NewWaitingChanges(len(changes), o.WaitingChangesOpts, identifiedResources, msgsUI)
+	defer waitingChanges.Stop()
+
+	waitingChanges.Track(changes)
+
+	for !waitingChanges.IsEmpty() {
+		_, err := waitingChanges.WaitForAny()
+		if err != nil {
+			return err
+		}
+	}
+
+	return waitingChanges.Complete()
+}
+
+func (o *WaitOptions) conf(coreClient kubernetes.Interface) (ctlconf.Conf, error) {
+	var allResources []ctlres.Resource
+
+	for _, file := range o.ConfigFiles {
+		fileRs, err := ctlres.NewFileResources(file)
+		if err != nil {
+			return ctlconf.Conf{}, err
+		}
+
+		for _, fileRes := range fileRs {
+			resources, err := fileRes.Resources()
+			if err != nil {
+				return ctlconf.Conf{}, err
+			}
+
+			allResources = append(allResources, resources...)
+		}
+	}
+
+	conf, err := waitConf(coreClient, o.AppFlags.NamespaceFlags.Name, allResources, o.ConfigFlags)
+	if err != nil {
+		return ctlconf.Conf{}, err
+	}
+
+	if conf.HasExternalWaitChecks() && !o.AllowExternalWaitChecks {
+		return ctlconf.Conf{}, fmt.Errorf("Expected to find flag '--wait-allow-external-checks' " +
+			"since config specifies wait rules with external checks")
+	}
+
+	return conf, nil
+}
@@ -0,0 +1,34 @@
+package app
+
+import (
+	cmdtools "github.com/k14s/kapp/pkg/kapp/cmd/tools"
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	"k8s.io/client-go/kubernetes"
+)
+
+// waitConf builds config used to determine waiting state of resources
+// (e.g. wait rules) out of default, cluster and provided config
+// for commands that do not deploy (e.g. wait, inspect, delete).
+// Non-config resources are ignored so that same files
+// could be provided to both deploy and wait commands.
+func waitConf(coreClient kubernetes.Interface, nsName string,
+	resources []ctlres.Resource, configFlags cmdtools.ConfigFlags) (ctlconf.Conf, error) {
+
+	clusterConfigRs, err := ctlconf.NewClusterConfigResources(coreClient, nsName)
+	if err != nil {
+		return ctlconf.Conf{}, err
+	}
+
+	confSrcs, err := configFlags.ConfSources(clusterConfigRs)
+	if err != nil {
+		return ctlconf.Conf{}, err
+	}
+
+	_, conf, err := ctlconf.NewConfFromResourcesWithSources(resources, confSrcs)
+	if err != nil {
+		return ctlconf.Conf{}, err
+	}
+
+	return conf, nil
+}
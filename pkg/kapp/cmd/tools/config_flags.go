@@ -0,0 +1,46 @@
+package tools
+
+import (
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	"github.com/spf13/cobra"
+)
+
+type ConfigFlags struct {
+	Files           []string
+	NoDefaultConfig bool
+}
+
+func (s *ConfigFlags) Set(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&s.Files, "config", nil,
+		"Set file with kapp config (format: /tmp/foo, https://..., -) (can repeat)")
+	cmd.Flags().BoolVar(&s.NoDefaultConfig, "no-default-config", false,
+		"Exclude built-in config (only provided and cluster config is used)")
+}
+
+// ConfSources returns config sources based on flags
+// (cluster config resources are provided by the caller)
+func (s ConfigFlags) ConfSources(clusterRs []ctlres.Resource) (ctlconf.ConfSources, error) {
+	srcs := ctlconf.ConfSources{
+		NoDefaults: s.NoDefaultConfig,
+		Cluster:    clusterRs,
+	}
+
+	for _, file := range s.Files {
+		fileRs, err := ctlres.NewFileResources(file)
+		if err != nil {
+			return ctlconf.ConfSources{}, err
+		}
+
+		for _, fileRes := range fileRs {
+			resources, err := fileRes.Resources()
+			if err != nil {
+				return ctlconf.ConfSources{}, err
+			}
+
+			srcs.Files = append(srcs.Files, resources...)
+		}
+	}
+
+	return srcs, nil
+}
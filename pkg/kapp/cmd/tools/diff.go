@@ -93,6 +93,7 @@ var _ ctlcap.ChangeView = DiffChangeView{}
 
 func (v DiffChangeView) Resource() ctlres.Resource         { return v.change.NewOrExistingResource() }
 func (v DiffChangeView) ExistingResource() ctlres.Resource { return v.change.ExistingResource() }
+func (v DiffChangeView) AppliedResource() ctlres.Resource  { return v.change.AppliedResource() }
 
 func (v DiffChangeView) ApplyOp() ctlcap.ClusterChangeApplyOp {
 	switch v.change.Op() {
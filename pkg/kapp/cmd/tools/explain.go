@@ -0,0 +1,152 @@
+package tools
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	ctlcap "github.com/k14s/kapp/pkg/kapp/clusterapply"
+	cmdcore "github.com/k14s/kapp/pkg/kapp/cmd/core"
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	"github.com/spf13/cobra"
+)
+
+type ExplainOptions struct {
+	ui          ui.UI
+	depsFactory cmdcore.DepsFactory
+
+	FileFlags     FileFlags
+	ConfigFlags   ConfigFlags
+	OverrideFlags OverrideFlags
+	Resources     []string
+
+	DefaultUpdateStrategy string
+	WaitTimeout           time.Duration
+}
+
+func NewExplainOptions(ui ui.UI, depsFactory cmdcore.DepsFactory) *ExplainOptions {
+	return &ExplainOptions{ui: ui, depsFactory: depsFactory}
+}
+
+func NewExplainCmd(o *ExplainOptions, flagsFactory cmdcore.FlagsFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain",
+		Short: "Explain which config rules and annotations affect resources",
+		RunE:  func(_ *cobra.Command, _ []string) error { return o.Run() },
+		Example: `
+  # Explain how Deployment 'app' in namespace 'default' is handled
+  kapp tools explain -f config/ --resource Deployment/default/app
+
+  # Explain how cluster scoped resource is handled
+  kapp tools explain -f config/ --resource ClusterRole/app-role`,
+	}
+	o.FileFlags.Set(cmd)
+	o.ConfigFlags.Set(cmd)
+	o.OverrideFlags.Set(cmd)
+	cmd.Flags().StringSliceVar(&o.Resources, "resource", nil,
+		"Set resource to explain (format: kind/namespace/name or kind/name) (can repeat; defaults to all)")
+	cmd.Flags().StringVar(&o.DefaultUpdateStrategy, "apply-default-update-strategy", "", "Change default update strategy")
+	cmd.Flags().DurationVar(&o.WaitTimeout, "wait-timeout", 15*time.Minute, "Maximum amount of time to wait for each change")
+	return cmd
+}
+
+func (o *ExplainOptions) Run() error {
+	var allResources []ctlres.Resource
+
+	for _, file := range o.FileFlags.Files {
+		fileRs, err := ctlres.NewFileResources(file)
+		if err != nil {
+			return err
+		}
+
+		for _, fileRes := range fileRs {
+			resources, err := fileRes.Resources()
+			if err != nil {
+				return err
+			}
+
+			allResources = append(allResources, resources...)
+		}
+	}
+
+	// Cluster config is not included since files are explained without cluster access
+	confSrcs, err := o.ConfigFlags.ConfSources(nil)
+	if err != nil {
+		return err
+	}
+
+	resources, conf, err := ctlconf.NewConfFromResourcesWithSources(allResources, confSrcs)
+	if err != nil {
+		return err
+	}
+
+	resources, err = o.selectedResources(resources)
+	if err != nil {
+		return err
+	}
+
+	overrideMods, err := o.OverrideFlags.Mods()
+	if err != nil {
+		return err
+	}
+
+	// Mods are applied same as during deploy since they may
+	// affect explained behaviour (e.g. update strategy annotation)
+	mods := append(append(conf.AnnotationMods(), conf.OverrideMods()...), overrideMods...)
+
+	for _, res := range resources {
+		for _, mod := range mods {
+			err := mod.Apply(res)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	explainOpts := ctlcap.ResourceExplanationOpts{
+		Conf:                  conf,
+		DefaultUpdateStrategy: o.DefaultUpdateStrategy,
+		WaitTimeout:           o.WaitTimeout,
+	}
+
+	var explanations []ctlcap.ResourceExplanation
+
+	for _, res := range resources {
+		explanations = append(explanations, ctlcap.NewResourceExplanation(res, explainOpts))
+	}
+
+	ctlcap.ResourceExplanationsView{explanations}.Print(o.ui)
+
+	return nil
+}
+
+func (o *ExplainOptions) selectedResources(resources []ctlres.Resource) ([]ctlres.Resource, error) {
+	if len(o.Resources) == 0 {
+		return resources, nil
+	}
+
+	var result []ctlres.Resource
+
+	for _, resStr := range o.Resources {
+		matcher, err := NewKindNamespaceNameMatcherFromString(resStr)
+		if err != nil {
+			return nil, err
+		}
+
+		var found bool
+
+		for _, res := range resources {
+			if matcher.Matches(res) {
+				result = append(result, res)
+				found = true
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("Expected to find resource '%s' in provided files", resStr)
+		}
+	}
+
+	return result, nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/fatih/color"
 	ctlcap "github.com/k14s/kapp/pkg/kapp/clusterapply"
 	cmdcore "github.com/k14s/kapp/pkg/kapp/cmd/core"
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
 	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
 )
 
@@ -17,6 +18,9 @@ type InspectTreeView struct {
 	Source    string
 	Resources []ctlres.Resource
 	Sort      bool
+	WaitRules []ctlconf.WaitRule
+
+	ChangedUIDs map[string]struct{} // highlighted (e.g. while watching)
 }
 
 func (v InspectTreeView) Print(ui ui.UI) {
@@ -60,7 +64,11 @@ func (v InspectTreeView) Print(ui ui.UI) {
 			{Column: 1, Asc: true},
 		},
 
-		Notes: []string{"Rs: Reconcile state", "Ri: Reconcile information"},
+		Notes: []string{
+			"Owner: kapp (created by kapp), cluster (created by controllers or other tools)",
+			"Rs: Reconcile state",
+			"Ri: Reconcile information",
+		},
 	}
 
 	rsByUID := map[string]ctlres.Resource{}
@@ -77,15 +85,22 @@ func (v InspectTreeView) Print(ui ui.UI) {
 			prefix = " L" + strings.Repeat("..", assocSortingVal.Depth()-1) + " "
 		}
 
+		name := resource.Name()
+		_, changed := v.ChangedUIDs[resource.UID()]
+
 		row := []uitable.Value{
 			uitable.NewValueString(assocSortingVal.Value()),
 			cmdcore.NewValueNamespace(resource.Namespace()),
 			ValueColored{
 				// TODO better composability
-				S: prefix + resource.Name(),
+				S: prefix + name,
 				Func: func(str string, opts ...interface{}) string {
 					result := fmt.Sprintf(str, opts...)
-					return strings.Replace(result, prefix, color.New(color.Faint).Sprintf("%s", prefix), 1)
+					styledName := name
+					if changed {
+						styledName = color.New(color.Bold).Sprintf("%s", name)
+					}
+					return strings.Replace(result, prefix+name, color.New(color.Faint).Sprintf("%s", prefix)+styledName, 1)
 				},
 			},
 			uitable.NewValueString(resource.Kind()),
@@ -95,7 +110,7 @@ func (v InspectTreeView) Print(ui ui.UI) {
 
 		if resource.IsProvisioned() {
 			condVal := cmdcore.NewConditionsValue(resource.Status())
-			syncVal := ctlcap.NewValueResourceConverged(resource)
+			syncVal := ctlcap.NewValueResourceConverged(resource, v.WaitRules)
 
 			row = append(row,
 				// TODO erroneously colors empty value
@@ -148,9 +163,46 @@ func (a *assocSortingValue) calculateValue() []string {
 	if len(lblVal) > 0 {
 		return []string{lblVal, a.uidOwnersStr()}
 	}
+	ancestorVal := a.labeledAncestorStr()
+	if len(ancestorVal) > 0 {
+		return []string{ancestorVal}
+	}
 	return []string{a.uidOwnersStr()}
 }
 
+// labeledAncestorStr nests resources without association label (e.g. created
+// by controllers that do not propagate labels) under their closest labeled owner
+func (a *assocSortingValue) labeledAncestorStr() string {
+	uids := []string{a.resource.UID()}
+	res := a.resource
+
+	// Bound number of steps in case of cyclic owner references
+	for i := 0; i < len(a.rsByUID); i++ {
+		var ownerRes *ctlres.Resource
+
+		for _, ref := range res.OwnerRefs() {
+			if foundRes, found := a.rsByUID[string(ref.UID)]; found {
+				ownerRes = &foundRes
+				break
+			}
+		}
+
+		if ownerRes == nil {
+			return ""
+		}
+
+		ownerLblVal := newAssocSortingValue(*ownerRes, a.rsByUID).labelAssocStr()
+		if len(ownerLblVal) > 0 {
+			return ownerLblVal + "/" + strings.Join(uids, "/")
+		}
+
+		uids = append([]string{(*ownerRes).UID()}, uids...)
+		res = *ownerRes
+	}
+
+	return ""
+}
+
 func (a *assocSortingValue) labelAssocStr() string {
 	lblVal := a.resource.Labels()[ctlres.NewAssociationLabel(a.resource).Key()]
 	if len(lblVal) > 0 {
@@ -2,11 +2,14 @@ package tools
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/cppforlife/go-cli-ui/ui"
 	uitable "github.com/cppforlife/go-cli-ui/ui/table"
+	"github.com/fatih/color"
 	ctlcap "github.com/k14s/kapp/pkg/kapp/clusterapply"
 	cmdcore "github.com/k14s/kapp/pkg/kapp/cmd/core"
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
 	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
 )
 
@@ -14,6 +17,9 @@ type InspectView struct {
 	Source    string
 	Resources []ctlres.Resource
 	Sort      bool
+	WaitRules []ctlconf.WaitRule
+
+	ChangedUIDs map[string]struct{} // highlighted (e.g. while watching)
 }
 
 func (v InspectView) Print(ui ui.UI) {
@@ -63,7 +69,7 @@ func (v InspectView) Print(ui ui.UI) {
 	for _, resource := range v.Resources {
 		row := []uitable.Value{
 			cmdcore.NewValueNamespace(resource.Namespace()),
-			uitable.NewValueString(resource.Name()),
+			NewValueResourceName(resource, v.ChangedUIDs),
 			uitable.NewValueString(resource.Kind()),
 			uitable.NewValueString(resource.APIVersion()),
 			NewValueResourceOwner(resource),
@@ -71,7 +77,7 @@ func (v InspectView) Print(ui ui.UI) {
 
 		if resource.IsProvisioned() {
 			condVal := cmdcore.NewConditionsValue(resource.Status())
-			syncVal := ctlcap.NewValueResourceConverged(resource)
+			syncVal := ctlcap.NewValueResourceConverged(resource, v.WaitRules)
 
 			row = append(row,
 				// TODO erroneously colors empty value
@@ -104,3 +110,24 @@ func NewValueResourceOwner(resource ctlres.Resource) uitable.ValueString {
 	}
 	return uitable.NewValueString("")
 }
+
+func NewValueResourceName(resource ctlres.Resource, changedUIDs map[string]struct{}) uitable.Value {
+	val := uitable.NewValueString(resource.Name())
+	if _, found := changedUIDs[resource.UID()]; found {
+		return ValueHighlighted{val}
+	}
+	return val
+}
+
+// ValueHighlighted formats value in bold while preserving sorting of wrapped value
+type ValueHighlighted struct {
+	V uitable.Value
+}
+
+func (t ValueHighlighted) String() string                  { return t.V.String() }
+func (t ValueHighlighted) Value() uitable.Value            { return t.V.Value() }
+func (t ValueHighlighted) Compare(other uitable.Value) int { panic("Never called") }
+
+func (t ValueHighlighted) Fprintf(w io.Writer, pattern string, rest ...interface{}) (int, error) {
+	return fmt.Fprintf(w, "%s", color.New(color.Bold).Sprintf(pattern, rest...))
+}
@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	"github.com/spf13/cobra"
+)
+
+type OverrideFlags struct {
+	ImageOverrides []string
+	Replicas       []string
+}
+
+func (s *OverrideFlags) Set(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&s.ImageOverrides, "image-override", nil,
+		"Set image for containers with given name (format: name=image) (can repeat)")
+	cmd.Flags().StringSliceVar(&s.Replicas, "set-replicas", nil,
+		"Set number of replicas for resource (format: kind/namespace/name=3 or kind/name=3; "+
+			"namespace as specified in files) (can repeat)")
+}
+
+// Mods returns mods that should be applied on top of config override rules
+func (s OverrideFlags) Mods() ([]ctlres.ResourceMod, error) {
+	var mods []ctlres.ResourceMod
+
+	for _, val := range s.ImageOverrides {
+		pieces := strings.SplitN(val, "=", 2)
+		if len(pieces) != 2 || len(pieces[0]) == 0 || len(pieces[1]) == 0 {
+			return nil, fmt.Errorf("Expected image override '%s' to be in format name=image", val)
+		}
+
+		// Containers may be nested at various depths (e.g. Pod vs CronJob)
+		for _, containersKey := range []string{"containers", "initContainers"} {
+			mods = append(mods, ctlres.FieldSetMod{
+				ResourceMatcher: ctlres.AllResourceMatcher{},
+				Path: ctlres.Path{
+					ctlres.NewPathPartRecursive(),
+					ctlres.NewPathPartFromString(containersKey),
+					ctlres.NewPathPartFromMatchField("name", pieces[0]),
+					ctlres.NewPathPartFromString("image"),
+				},
+				Value: pieces[1],
+			})
+		}
+	}
+
+	for _, val := range s.Replicas {
+		pieces := strings.SplitN(val, "=", 2)
+		if len(pieces) != 2 {
+			return nil, fmt.Errorf("Expected replicas '%s' to be in format kind/namespace/name=3 or kind/name=3", val)
+		}
+
+		matcher, err := NewKindNamespaceNameMatcherFromString(pieces[0])
+		if err != nil {
+			return nil, err
+		}
+
+		replicas, err := strconv.ParseInt(pieces[1], 10, 32)
+		if err != nil || replicas < 0 {
+			return nil, fmt.Errorf("Expected replicas '%s' to be a non-negative integer", pieces[1])
+		}
+
+		mods = append(mods, ctlres.FieldSetMod{
+			ResourceMatcher: matcher,
+			Path:            ctlres.NewPathFromStrings([]string{"spec", "replicas"}),
+			Value:           replicas,
+		})
+	}
+
+	return mods, nil
+}
+
+// NewKindNamespaceNameMatcherFromString parses kind/namespace/name or kind/name
+func NewKindNamespaceNameMatcherFromString(str string) (ctlres.KindNamespaceNameMatcher, error) {
+	pieces := strings.Split(str, "/")
+
+	switch len(pieces) {
+	case 2:
+		return ctlres.KindNamespaceNameMatcher{Kind: pieces[0], Name: pieces[1]}, nil
+	case 3:
+		return ctlres.KindNamespaceNameMatcher{Kind: pieces[0], Namespace: pieces[1], Name: pieces[2]}, nil
+	default:
+		return ctlres.KindNamespaceNameMatcher{}, fmt.Errorf(
+			"Expected resource '%s' to be in format kind/namespace/name or kind/name", str)
+	}
+}
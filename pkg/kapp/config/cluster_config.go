@@ -0,0 +1,94 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// Label of ConfigMaps in state namespace that hold cluster wide config
+	// (each data key may contain one or more config resources).
+	// Label is used instead of well-known name since app records
+	// are ConfigMaps named after apps in the same namespace.
+	ClusterConfigLabelKey   = "kapp.k14s.io/is-cluster-config"
+	clusterConfigLabelValue = ""
+)
+
+// NewClusterConfigResources returns config resources stored in
+// labeled ConfigMaps within state namespace (ordered by name)
+func NewClusterConfigResources(coreClient kubernetes.Interface, nsName string) ([]ctlres.Resource, error) {
+	listOpts := metav1.ListOptions{
+		LabelSelector: labels.Set{ClusterConfigLabelKey: clusterConfigLabelValue}.String(),
+	}
+
+	configMaps, err := coreClient.CoreV1().ConfigMaps(nsName).List(listOpts)
+	if err != nil {
+		return nil, fmt.Errorf("Listing cluster config ConfigMaps (namespace: %s): %s", nsName, err)
+	}
+
+	sort.Slice(configMaps.Items, func(i, j int) bool {
+		return configMaps.Items[i].Name < configMaps.Items[j].Name
+	})
+
+	var result []ctlres.Resource
+
+	for _, configMap := range configMaps.Items {
+		resources, err := clusterConfigMapResources(configMap)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resources...)
+	}
+
+	return result, nil
+}
+
+func clusterConfigMapResources(configMap corev1.ConfigMap) ([]ctlres.Resource, error) {
+	var keys []string
+	for key := range configMap.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var result []ctlres.Resource
+
+	for _, key := range keys {
+		src := clusterConfigSource{configMap.Namespace, configMap.Name, key, []byte(configMap.Data[key])}
+
+		resources, err := ctlres.NewFileResource(src).Resources()
+		if err != nil {
+			return nil, fmt.Errorf("Reading %s: %s", src.Description(), err)
+		}
+
+		for _, res := range resources {
+			if !IsConfigResource(res) {
+				return nil, fmt.Errorf("Expected only config resources in %s, but found '%s'", src.Description(), res.Description())
+			}
+		}
+
+		result = append(result, resources...)
+	}
+
+	return result, nil
+}
+
+type clusterConfigSource struct {
+	nsName string
+	name   string
+	key    string
+	bytes  []byte
+}
+
+var _ ctlres.FileSource = clusterConfigSource{}
+
+func (s clusterConfigSource) Description() string {
+	return fmt.Sprintf("configmap '%s/%s' key '%s'", s.nsName, s.name, s.key)
+}
+
+func (s clusterConfigSource) Bytes() ([]byte, error) { return s.bytes, nil }
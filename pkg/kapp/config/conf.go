@@ -15,17 +15,12 @@ func NewConfFromResources(resources []ctlres.Resource) ([]ctlres.Resource, Conf,
 	var configs []Config
 
 	for _, res := range resources {
-		if res.APIVersion() == configAPIVersion {
-			if res.Kind() == configKind {
-				config, err := NewConfigFromResource(res)
-				if err != nil {
-					return nil, Conf{}, err
-				}
-				configs = append(configs, config)
-			} else {
-				errMsg := "Unexpected kind in resource '%s', wanted '%s'"
-				return nil, Conf{}, fmt.Errorf(errMsg, res.Description(), configKind)
+		if IsConfigResource(res) {
+			config, err := NewConfigFromConfigResource(res)
+			if err != nil {
+				return nil, Conf{}, err
 			}
+			configs = append(configs, config)
 		} else {
 			rsWithoutConfigs = append(rsWithoutConfigs, res)
 		}
@@ -34,14 +29,34 @@ func NewConfFromResources(resources []ctlres.Resource) ([]ctlres.Resource, Conf,
 	return rsWithoutConfigs, Conf{configs}, nil
 }
 
-func (c Conf) RebaseMods() []ctlres.ResourceModWithMultiple {
+// IsConfigResource returns true for resources that are meant
+// to configure kapp (hence are not deployed to the cluster)
+func IsConfigResource(res ctlres.Resource) bool {
+	return res.APIVersion() == configAPIVersion
+}
+
+// NewConfigFromConfigResource is similar to NewConfigFromResource
+// but additionally checks that resource is of expected kind
+func NewConfigFromConfigResource(res ctlres.Resource) (Config, error) {
+	if res.Kind() != configKind {
+		errMsg := "Unexpected kind in resource '%s'%s, wanted '%s'"
+		return Config{}, fmt.Errorf(errMsg, res.Description(), configOriginDesc(res), configKind)
+	}
+	return NewConfigFromResource(res)
+}
+
+func (c Conf) RebaseMods() ([]ctlres.ResourceModWithMultiple, error) {
 	var mods []ctlres.ResourceModWithMultiple
 	for _, config := range c.configs {
 		for _, rule := range config.RebaseRules {
-			mods = append(mods, rule.AsMods()...)
+			ruleMods, err := rule.AsMods()
+			if err != nil {
+				return nil, err
+			}
+			mods = append(mods, ruleMods...)
 		}
 	}
-	return mods
+	return mods, nil
 }
 
 func (c Conf) DiffAgainstLastAppliedFieldExclusionMods() []ctlres.FieldRemoveMod {
@@ -86,6 +101,55 @@ func (c Conf) TemplateRules() []TemplateRule {
 	return result
 }
 
+func (c Conf) WaitRules() []WaitRule {
+	var result []WaitRule
+	for _, config := range c.configs {
+		result = append(result, config.WaitRules...)
+	}
+	return result
+}
+
+func (c Conf) OwnerReferenceAssociationRules() []OwnerReferenceAssociationRule {
+	var result []OwnerReferenceAssociationRule
+	for _, config := range c.configs {
+		result = append(result, config.OwnerReferenceAssociationRules...)
+	}
+	return result
+}
+
+func (c Conf) AnnotationMods() []ctlres.ResourceMod {
+	var mods []ctlres.ResourceMod
+	for _, config := range c.configs {
+		for _, rule := range config.AnnotationRules {
+			for _, mod := range rule.AsMods() {
+				mods = append(mods, mod)
+			}
+		}
+	}
+	return mods
+}
+
+func (c Conf) OverrideMods() []ctlres.ResourceMod {
+	var mods []ctlres.ResourceMod
+	for _, config := range c.configs {
+		for _, rule := range config.OverrideRules {
+			for _, mod := range rule.AsMods() {
+				mods = append(mods, mod)
+			}
+		}
+	}
+	return mods
+}
+
+func (c Conf) HasExternalWaitChecks() bool {
+	for _, rule := range c.WaitRules() {
+		if rule.ExternalCheck != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func (c Conf) AdditionalLabels() map[string]string {
 	result := map[string]string{}
 	for _, config := range c.configs {
@@ -1,10 +1,17 @@
 package config
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
 
 	"github.com/ghodss/yaml"
 	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 const (
@@ -15,14 +22,22 @@ const (
 type Config struct {
 	APIVersion string `json:"apiVersion"`
 	Kind       string
+	Metadata   map[string]interface{} // not used
 
 	RebaseRules         []RebaseRule
 	OwnershipLabelRules []OwnershipLabelRule
 	LabelScopingRules   []LabelScopingRule
 	TemplateRules       []TemplateRule
+	WaitRules           []WaitRule
+
+	OwnerReferenceAssociationRules []OwnerReferenceAssociationRule
+	AnnotationRules                []AnnotationRule
+	OverrideRules                  []OverrideRule
 
 	AdditionalLabels                          map[string]string
 	DiffAgainstLastAppliedFieldExclusionRules []DiffAgainstLastAppliedFieldExclusionRule
+
+	desc string // used to identify config when explaining rules
 }
 
 type RebaseRule struct {
@@ -30,6 +45,10 @@ type RebaseRule struct {
 	Path             ctlres.Path
 	Type             string
 	Sources          []ctlres.FieldCopyModSource
+
+	// Optional conditions for copy type
+	IfNewEmpty        bool   // copy only if new value is missing or empty
+	IfExistingMatches string // copy only if existing value matches regexp
 }
 
 type DiffAgainstLastAppliedFieldExclusionRule struct {
@@ -60,6 +79,110 @@ type TemplateAffectedResources struct {
 type TemplateAffectedObjRef struct {
 	ResourceMatchers []ResourceMatcher
 	Path             ctlres.Path
+	NameKey          string // defaults to 'name' (example: 'secretName' for Secret volumes)
+}
+
+func (r TemplateAffectedObjRef) NameKeyOrDefault() string {
+	if len(r.NameKey) > 0 {
+		return r.NameKey
+	}
+	return "name"
+}
+
+type WaitRule struct {
+	ResourceMatchers []ResourceMatcher
+	Timeout          *metav1.Duration // example: 30m
+
+	SupportsObservedGeneration bool
+	ConditionMatchers          []WaitRuleConditionMatcher
+	FieldMatchers              []WaitRuleFieldMatcher
+	ExternalCheck              *WaitRuleExternalCheck
+}
+
+type WaitRuleConditionMatcher struct {
+	Type    string
+	Status  string
+	Success bool
+	Failure bool
+}
+
+type WaitRuleFieldMatcher struct {
+	Path    ctlres.Path
+	Value   string // compared against string form of found value
+	Success bool
+	Failure bool
+}
+
+// WaitRuleExternalCheck specifies executable that receives resource (and its
+// associated resources) as JSON on stdin and prints its waiting state as JSON
+type WaitRuleExternalCheck struct {
+	Command string
+	Args    []string
+	Timeout *metav1.Duration // defaults to 30s
+}
+
+// DefinesWaiting returns false for rules that only configure timeout
+func (r WaitRule) DefinesWaiting() bool {
+	return r.SupportsObservedGeneration || len(r.ConditionMatchers) > 0 ||
+		len(r.FieldMatchers) > 0 || r.ExternalCheck != nil
+}
+
+// OwnerReferenceAssociationRule makes resources of listed types that are owned
+// (via ownerReferences) by matching resources count as their associated resources
+type OwnerReferenceAssociationRule struct {
+	ResourceMatchers   []ResourceMatcher
+	OwnedResourceTypes []APIVersionKindMatcher
+}
+
+func (r OwnerReferenceAssociationRule) AsOwnedResourceTypes() []ctlres.APIVersionKindMatcher {
+	var result []ctlres.APIVersionKindMatcher
+	for _, matcher := range r.OwnedResourceTypes {
+		result = append(result, ctlres.APIVersionKindMatcher{APIVersion: matcher.APIVersion, Kind: matcher.Kind})
+	}
+	return result
+}
+
+// AnnotationRule adds annotations to matching resources before they are
+// prepared for deploy (e.g. to configure kapp behaviour via kapp.k14s.io/*
+// annotations without editing resources). Annotations already present
+// on resources are kept unless override is set.
+type AnnotationRule struct {
+	ResourceMatchers []ResourceMatcher
+	Annotations      map[string]string
+	Override         bool
+}
+
+func (r AnnotationRule) AsMods() []ctlres.StringMapAppendMod {
+	var mods []ctlres.StringMapAppendMod
+	for _, matcher := range r.ResourceMatchers {
+		mods = append(mods, ctlres.StringMapAppendMod{
+			ResourceMatcher: matcher.AsResourceMatcher(),
+			Path:            ctlres.NewPathFromStrings([]string{"metadata", "annotations"}),
+			SkipIfKeyFound:  !r.Override,
+			KVs:             r.Annotations,
+		})
+	}
+	return mods
+}
+
+// OverrideRule sets value at path within matching resources before they are
+// prepared for deploy (e.g. to pin images or replicas per environment)
+type OverrideRule struct {
+	ResourceMatchers []ResourceMatcher
+	Path             ctlres.Path
+	Value            interface{}
+}
+
+func (r OverrideRule) AsMods() []ctlres.FieldSetMod {
+	var mods []ctlres.FieldSetMod
+	for _, matcher := range r.ResourceMatchers {
+		mods = append(mods, ctlres.FieldSetMod{
+			ResourceMatcher: matcher.AsResourceMatcher(),
+			Path:            r.Path,
+			Value:           r.Value,
+		})
+	}
+	return mods
 }
 
 type ResourceMatchers []ResourceMatcher
@@ -67,7 +190,16 @@ type ResourceMatchers []ResourceMatcher
 type ResourceMatcher struct {
 	AllResourceMatcher       *AllResourceMatcher    // default
 	APIVersionKindMatcher    *APIVersionKindMatcher `json:"apiVersionKindMatcher"`
+	APIGroupKindMatcher      *APIGroupKindMatcher   `json:"apiGroupKindMatcher"`
 	KindNamespaceNameMatcher *KindNamespaceNameMatcher
+	NameMatcher              *NameMatcher
+	NamespaceMatcher         *NamespaceMatcher
+	LabelSelectorMatcher     *LabelSelectorMatcher
+	AnnotationMatcher        *AnnotationMatcher
+
+	AndMatcher *AndMatcher
+	OrMatcher  *OrMatcher
+	NotMatcher *NotMatcher
 }
 
 type AllResourceMatcher struct{}
@@ -77,6 +209,45 @@ type APIVersionKindMatcher struct {
 	Kind       string
 }
 
+// APIGroupKindMatcher matches all versions of a kind (empty group is core group)
+type APIGroupKindMatcher struct {
+	APIGroup string `json:"apiGroup"`
+	Kind     string
+}
+
+type NameMatcher struct {
+	Name string // supports globs (example: 'app-*')
+}
+
+type NamespaceMatcher struct {
+	Namespace string // supports globs (example: 'team-*')
+}
+
+type LabelSelectorMatcher struct {
+	Selector string // example: 'app=web,tier!=db'
+
+	parsedSelector labels.Selector // set when unmarshaled
+}
+
+var _ json.Unmarshaler = &LabelSelectorMatcher{}
+
+type AnnotationMatcher struct {
+	Key   string
+	Value *string // if not specified, only presence of annotation is checked
+}
+
+type AndMatcher struct {
+	Matchers []ResourceMatcher
+}
+
+type OrMatcher struct {
+	Matchers []ResourceMatcher
+}
+
+type NotMatcher struct {
+	Matcher ResourceMatcher
+}
+
 type KindNamespaceNameMatcher struct {
 	Kind      string
 	Namespace string
@@ -93,36 +264,80 @@ func NewConfigFromResource(res ctlres.Resource) (Config, error) {
 
 	err = yaml.Unmarshal(bs, &config)
 	if err != nil {
-		return Config{}, fmt.Errorf("Unmarshaling %s: %s", res.Description(), err)
+		return Config{}, fmt.Errorf("Unmarshaling %s%s: %s", res.Description(), configOriginDesc(res), err)
 	}
 
+	var obj interface{}
+
+	err = yaml.Unmarshal(bs, &obj)
+	if err != nil {
+		return Config{}, fmt.Errorf("Unmarshaling %s%s: %s", res.Description(), configOriginDesc(res), err)
+	}
+
+	errs := configUnknownFields(obj, reflect.TypeOf(config), "")
+	errs = append(errs, config.Validate()...)
+
+	if len(errs) > 0 {
+		var msgs []string
+		for _, err := range errs {
+			msgs = append(msgs, "- "+err.Error())
+		}
+		return Config{}, fmt.Errorf("Validating config %s%s:\n%s",
+			res.Description(), configOriginDesc(res), strings.Join(msgs, "\n"))
+	}
+
+	config.desc = res.Description() + configOriginDesc(res)
+
 	return config, nil
 }
 
-func (r RebaseRule) AsMods() []ctlres.ResourceModWithMultiple {
+func configOriginDesc(res ctlres.Resource) string {
+	if len(res.Origin()) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", res.Origin())
+}
+
+func (r RebaseRule) AsMods() ([]ctlres.ResourceModWithMultiple, error) {
 	var mods []ctlres.ResourceModWithMultiple
 
 	for _, matcher := range r.ResourceMatchers {
 		switch r.Type {
-		case "copy":
-			mods = append(mods, ctlres.FieldCopyMod{
+		case rebaseRuleTypeCopy:
+			mod := ctlres.FieldCopyMod{
+				ResourceMatcher:    matcher.AsResourceMatcher(),
+				Path:               r.Path,
+				Sources:            r.Sources,
+				IfDestinationEmpty: r.IfNewEmpty,
+			}
+			if len(r.IfExistingMatches) > 0 {
+				regex, err := regexp.Compile(r.IfExistingMatches)
+				if err != nil {
+					return nil, fmt.Errorf("Compiling rebase rule regexp '%s': %s", r.IfExistingMatches, err)
+				}
+				mod.IfSourceMatches = regex
+			}
+			mods = append(mods, mod)
+
+		case rebaseRuleTypeMerge:
+			mods = append(mods, ctlres.FieldMergeMod{
 				ResourceMatcher: matcher.AsResourceMatcher(),
 				Path:            r.Path,
 				Sources:         r.Sources,
 			})
 
-		case "remove":
+		case rebaseRuleTypeRemove:
 			mods = append(mods, ctlres.FieldRemoveMod{
 				ResourceMatcher: matcher.AsResourceMatcher(),
 				Path:            r.Path,
 			})
 
 		default:
-			panic(fmt.Sprintf("Unknown rebase rule type: %s (supported: copy, remove)", r.Type)) // TODO
+			return nil, r.unknownTypeErr("rebaseRule")
 		}
 	}
 
-	return mods
+	return mods, nil
 }
 
 func (r DiffAgainstLastAppliedFieldExclusionRule) AsMods() []ctlres.FieldRemoveMod {
@@ -154,6 +369,15 @@ func (ms ResourceMatchers) AsResourceMatchers() []ctlres.ResourceMatcher {
 
 func (m ResourceMatcher) AsResourceMatcher() ctlres.ResourceMatcher {
 	switch {
+	case m.AndMatcher != nil:
+		return ctlres.AndMatcher{ResourceMatchers(m.AndMatcher.Matchers).AsResourceMatchers()}
+
+	case m.OrMatcher != nil:
+		return ctlres.AnyMatcher{ResourceMatchers(m.OrMatcher.Matchers).AsResourceMatchers()}
+
+	case m.NotMatcher != nil:
+		return ctlres.NotMatcher{m.NotMatcher.Matcher.AsResourceMatcher()}
+
 	case m.KindNamespaceNameMatcher != nil:
 		return ctlres.KindNamespaceNameMatcher{
 			Kind:      m.KindNamespaceNameMatcher.Kind,
@@ -167,7 +391,62 @@ func (m ResourceMatcher) AsResourceMatcher() ctlres.ResourceMatcher {
 			Kind:       m.APIVersionKindMatcher.Kind,
 		}
 
+	case m.APIGroupKindMatcher != nil:
+		return ctlres.APIGroupKindMatcher{
+			APIGroup: m.APIGroupKindMatcher.APIGroup,
+			Kind:     m.APIGroupKindMatcher.Kind,
+		}
+
+	case m.NameMatcher != nil:
+		return ctlres.NameMatcher{Name: m.NameMatcher.Name}
+
+	case m.NamespaceMatcher != nil:
+		return ctlres.NamespaceMatcher{Namespace: m.NamespaceMatcher.Namespace}
+
+	case m.LabelSelectorMatcher != nil:
+		return ctlres.LabelSelectorMatcher{Selector: m.LabelSelectorMatcher.AsSelector()}
+
+	case m.AnnotationMatcher != nil:
+		return ctlres.AnnotationMatcher{Key: m.AnnotationMatcher.Key, Value: m.AnnotationMatcher.Value}
+
 	default:
 		return ctlres.AllResourceMatcher{}
 	}
 }
+
+func (m *LabelSelectorMatcher) UnmarshalJSON(data []byte) error {
+	var matcher struct {
+		Selector string
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	err := dec.Decode(&matcher)
+	if err != nil {
+		return fmt.Errorf("Unmarshaling label selector matcher: %s", err)
+	}
+
+	sel, err := labels.Parse(matcher.Selector)
+	if err != nil {
+		return fmt.Errorf("Parsing label selector '%s': %s", matcher.Selector, err)
+	}
+
+	m.Selector = matcher.Selector
+	m.parsedSelector = sel
+	return nil
+}
+
+// AsSelector returns selector parsed during unmarshaling; matchers
+// constructed directly are parsed on demand (invalid selector panics
+// instead of silently matching nothing)
+func (m LabelSelectorMatcher) AsSelector() labels.Selector {
+	if m.parsedSelector != nil {
+		return m.parsedSelector
+	}
+	sel, err := labels.Parse(m.Selector)
+	if err != nil {
+		panic(fmt.Sprintf("Parsing label selector '%s': %s", m.Selector, err))
+	}
+	return sel
+}
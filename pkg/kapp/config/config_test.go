@@ -0,0 +1,182 @@
+package config_test
+
+import (
+	"strings"
+	"testing"
+
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+)
+
+func TestNewConfFromResourcesWithDefaultsValidates(t *testing.T) {
+	_, _, err := ctlconf.NewConfFromResourcesWithDefaults(nil)
+	if err != nil {
+		t.Fatalf("Expected default config to be valid, but was: %s", err)
+	}
+}
+
+func TestNewConfigFromResourceErrs(t *testing.T) {
+	res := ctlres.MustNewResourceFromBytes([]byte(`
+apiVersion: kapp.k14s.io/v1alpha1
+kind: Config
+rebaseRules:
+- path: [spec, {allIndexes: true}]
+  type: copi
+  sources: [new]
+  resourceMatchers: []
+  unknownField: true
+- path: [metadata, annotations]
+  type: merge
+  sources: [existing]
+  ifNewEmpty: true
+  resourceMatchers:
+  - allResourceMatcher: {}
+- path: [spec, clusterIP]
+  type: copy
+  sources: [new, existing]
+  ifExistingMatches: "10.("
+  resourceMatchers:
+  - allResourceMatcher: {}
+waitRules:
+- resourceMatchers:
+  - apiVersionKindMatcher: {apiVersion: v1}
+    nameMatcher: {name: app}
+  conditionMatchers:
+  - type: Ready
+    status: "True"
+    success: true
+  fieldMatchers:
+  - path: [status, {recursive: true}]
+    value: Running
+    success: true
+- resourceMatchers:
+  - allResourceMatcher: {}
+  conditionMatchers:
+  - type: Ready
+    status: "True"
+    success: true
+  externalCheck:
+    command: /usr/local/bin/check
+overrideRules:
+- path: [spec, {allIndexes: true}]
+  resourceMatchers:
+  - allResourceMatcher: {}
+  - labelSelectorMatcher: {}
+`))
+
+	_, err := ctlconf.NewConfigFromResource(res)
+	if err == nil {
+		t.Fatalf("Expected config to be invalid")
+	}
+
+	expectedErr := strings.TrimSpace(`
+Validating config config/ (kapp.k14s.io/v1alpha1) cluster:
+- rebaseRules[0].unknownField: Unknown field
+- rebaseRules[0].resourceMatchers: Expected at least one resource matcher
+- rebaseRules[0].path: Expected last path part to be a map key, allKeys or keyGlob
+- rebaseRules[0].type: Unknown rebase rule type 'copi' (supported: copy, merge, remove)
+- rebaseRules[1]: Expected conditions (ifNewEmpty, ifExistingMatches) only for rebase rule of type 'copy'
+- rebaseRules[2].ifExistingMatches: Expected sources to only include 'existing'
+- rebaseRules[2].ifExistingMatches: Expected valid regexp: error parsing regexp: missing closing ): ` + "`10.(`" + `
+- waitRules[0].resourceMatchers[0]: Expected exactly one matcher to be specified (e.g. allResourceMatcher, apiVersionKindMatcher, apiGroupKindMatcher), but found 2
+- waitRules[0].fieldMatchers[0].path: Expected recursive path part to be followed by other path parts
+- waitRules[1].externalCheck: Expected to not be used together with supportsObservedGeneration, conditionMatchers or fieldMatchers
+- overrideRules[0].resourceMatchers[1].labelSelectorMatcher.selector: Expected to be non-empty
+- overrideRules[0].path: Expected last path part to be a map key, allKeys or keyGlob
+- overrideRules[0].value: Expected to be non-null
+`)
+
+	if err.Error() != expectedErr {
+		t.Fatalf("Expected err to be >>>%s<<<, but was >>>%s<<<", expectedErr, err)
+	}
+}
+
+func TestNewConfFromResourcesWithSourcesOrder(t *testing.T) {
+	newConfigRes := func(timeout string) ctlres.Resource {
+		return ctlres.MustNewResourceFromBytes([]byte(`
+apiVersion: kapp.k14s.io/v1alpha1
+kind: Config
+waitRules:
+- resourceMatchers:
+  - allResourceMatcher: {}
+  timeout: ` + timeout))
+	}
+
+	appRes := ctlres.MustNewResourceFromBytes([]byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app
+`))
+
+	srcs := ctlconf.ConfSources{
+		NoDefaults: true,
+		Cluster:    []ctlres.Resource{newConfigRes("1m")},
+		Files:      []ctlres.Resource{newConfigRes("2m")},
+	}
+
+	rs, conf, err := ctlconf.NewConfFromResourcesWithSources([]ctlres.Resource{newConfigRes("3m"), appRes}, srcs)
+	if err != nil {
+		t.Fatalf("Expected no err, but was %s", err)
+	}
+
+	if len(rs) != 1 || rs[0].Name() != "app" {
+		t.Fatalf("Expected only non-config resources to be returned")
+	}
+
+	var timeouts []string
+	for _, rule := range conf.WaitRules() {
+		timeouts = append(timeouts, rule.Timeout.Duration.String())
+	}
+
+	if strings.Join(timeouts, ",") != "1m0s,2m0s,3m0s" {
+		t.Fatalf("Expected configs to be ordered by source, but was %s", timeouts)
+	}
+
+	srcs.Files = append(srcs.Files, appRes)
+
+	_, _, err = ctlconf.NewConfFromResourcesWithSources(nil, srcs)
+	if err == nil || !strings.Contains(err.Error(), "Expected only config resources") {
+		t.Fatalf("Expected err for non-config resource in config files, but was %v", err)
+	}
+}
+
+func TestLabelSelectorMatcherAsResourceMatcher(t *testing.T) {
+	config, err := ctlconf.NewConfigFromResource(ctlres.MustNewResourceFromBytes([]byte(`
+apiVersion: kapp.k14s.io/v1alpha1
+kind: Config
+waitRules:
+- resourceMatchers:
+  - labelSelectorMatcher: {selector: "tier=web"}
+`)))
+	if err != nil {
+		t.Fatalf("Expected no err, but was %s", err)
+	}
+
+	res := ctlres.MustNewResourceFromBytes([]byte(`
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+  labels:
+    tier: web
+`))
+
+	if !config.WaitRules[0].ResourceMatchers[0].AsResourceMatcher().Matches(res) {
+		t.Fatalf("Expected unmarshaled matcher to match")
+	}
+
+	matcher := ctlconf.ResourceMatcher{LabelSelectorMatcher: &ctlconf.LabelSelectorMatcher{Selector: "tier=web"}}
+	if !matcher.AsResourceMatcher().Matches(res) {
+		t.Fatalf("Expected constructed matcher to match")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected invalid constructed matcher to panic")
+		}
+	}()
+
+	matcher = ctlconf.ResourceMatcher{LabelSelectorMatcher: &ctlconf.LabelSelectorMatcher{Selector: "tier in (web"}}
+	matcher.AsResourceMatcher()
+}
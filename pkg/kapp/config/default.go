@@ -1,6 +1,8 @@
 package config
 
 import (
+	"fmt"
+
 	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
 )
 
@@ -92,10 +94,8 @@ rebaseRules:
   type: copy
   sources: [new, existing]
   resourceMatchers: &builtinAppsDeploymentWithRevAnnKey
-  - apiVersionKindMatcher: {apiVersion: apps/v1, kind: Deployment}
-  - apiVersionKindMatcher: {apiVersion: apps/v1beta1, kind: Deployment}
-  - apiVersionKindMatcher: {apiVersion: apps/v1beta2, kind: Deployment}
-  - apiVersionKindMatcher: {apiVersion: extensions/v1beta1, kind: Deployment}
+  - apiGroupKindMatcher: {apiGroup: apps, kind: Deployment}
+  - apiGroupKindMatcher: {apiGroup: extensions, kind: Deployment}
 
 diffAgainstLastAppliedFieldExclusionRules:
 - path: [metadata, annotations, "deployment.kubernetes.io/revision"]
@@ -108,46 +108,27 @@ ownershipLabelRules:
 
 - path: [spec, template, metadata, labels]
   resourceMatchers: &builtinAppsControllers
-  # Deployment
-  - apiVersionKindMatcher: {apiVersion: apps/v1, kind: Deployment}
-  - apiVersionKindMatcher: {apiVersion: apps/v1beta2, kind: Deployment}
-  - apiVersionKindMatcher: {apiVersion: apps/v1beta1, kind: Deployment}
-  - apiVersionKindMatcher: {apiVersion: extensions/v1beta1, kind: Deployment}
-  # ReplicaSet
-  - apiVersionKindMatcher: {apiVersion: apps/v1, kind: ReplicaSet}
-  - apiVersionKindMatcher: {apiVersion: apps/v1beta2, kind: ReplicaSet}
-  - apiVersionKindMatcher: {apiVersion: apps/v1beta1, kind: ReplicaSet}
-  - apiVersionKindMatcher: {apiVersion: extensions/v1beta1, kind: ReplicaSet}
-  # StatefulSet
-  - apiVersionKindMatcher: {apiVersion: apps/v1, kind: StatefulSet}
-  - apiVersionKindMatcher: {apiVersion: apps/v1beta2, kind: StatefulSet}
-  - apiVersionKindMatcher: {apiVersion: apps/v1beta1, kind: StatefulSet}
-  - apiVersionKindMatcher: {apiVersion: extensions/v1beta1, kind: StatefulSet}
-  # DaemonSet
-  - apiVersionKindMatcher: {apiVersion: apps/v1, kind: DaemonSet}
-  - apiVersionKindMatcher: {apiVersion: apps/v1beta2, kind: DaemonSet}
-  - apiVersionKindMatcher: {apiVersion: apps/v1beta1, kind: DaemonSet}
-  - apiVersionKindMatcher: {apiVersion: extensions/v1beta1, kind: DaemonSet}
+  - apiGroupKindMatcher: {apiGroup: apps, kind: Deployment}
+  - apiGroupKindMatcher: {apiGroup: extensions, kind: Deployment}
+  - apiGroupKindMatcher: {apiGroup: apps, kind: ReplicaSet}
+  - apiGroupKindMatcher: {apiGroup: extensions, kind: ReplicaSet}
+  - apiGroupKindMatcher: {apiGroup: apps, kind: StatefulSet}
+  - apiGroupKindMatcher: {apiGroup: apps, kind: DaemonSet}
+  - apiGroupKindMatcher: {apiGroup: extensions, kind: DaemonSet}
 
 # TODO It seems that these labels are being ignored
 # https://github.com/kubernetes/kubernetes/issues/74916
 - path: [spec, volumeClaimTemplates, {allIndexes: true}, metadata, labels]
   resourceMatchers:
-  # StatefulSet
-  - apiVersionKindMatcher: {apiVersion: apps/v1, kind: StatefulSet}
-  - apiVersionKindMatcher: {apiVersion: apps/v1beta1, kind: StatefulSet}
-  - apiVersionKindMatcher: {apiVersion: extensions/v1beta1, kind: StatefulSet}
+  - apiGroupKindMatcher: {apiGroup: apps, kind: StatefulSet}
 
 - path: [spec, template, metadata, labels]
   resourceMatchers:
-  - apiVersionKindMatcher: {apiVersion: batch/v1, kind: Job}
-  - apiVersionKindMatcher: {apiVersion: batch/v1beta1, kind: Job}
-  - apiVersionKindMatcher: {apiVersion: batch/v2alpha1, kind: Job}
+  - apiGroupKindMatcher: {apiGroup: batch, kind: Job}
 
 - path: [spec, jobTemplate, spec, template, metadata, labels]
   resourceMatchers:
-  - apiVersionKindMatcher: {apiVersion: batch/v1beta1, kind: CronJob}
-  - apiVersionKindMatcher: {apiVersion: batch/v2alpha1, kind: CronJob}
+  - apiGroupKindMatcher: {apiGroup: batch, kind: CronJob}
 
 labelScopingRules:
 - path: [spec, selector]
@@ -175,6 +156,11 @@ templateRules:
     - path: [spec, volumes, {allIndexes: true}, configMap]
       resourceMatchers:
       - apiVersionKindMatcher: {apiVersion: v1, kind: Pod}
+    - path: [spec, template, spec, volumes, {allIndexes: true}, projected, sources, {allIndexes: true}, configMap]
+      resourceMatchers: *builtinAppsControllers
+    - path: [spec, volumes, {allIndexes: true}, projected, sources, {allIndexes: true}, configMap]
+      resourceMatchers:
+      - apiVersionKindMatcher: {apiVersion: v1, kind: Pod}
 
 - resourceMatchers:
   - apiVersionKindMatcher: {apiVersion: v1, kind: Secret}
@@ -184,12 +170,18 @@ templateRules:
       resourceMatchers: *builtinAppsControllers
     - path: [spec, template, spec, containers, {allIndexes: true}, envFrom, {allIndexes: true}, secretRef]
       resourceMatchers: *builtinAppsControllers
-    # TODO uses secretName instead of name
-    #- path: [spec, template, spec, volumes, {allIndexes: true}, secret]
-    #  resourceMatchers: *builtinAppsControllers
-    #- path: [spec, volumes, {allIndexes: true}, secret]
-    #  resourceMatchers:
-    #  - apiVersionKindMatcher: {apiVersion: v1, kind: Pod}
+    - path: [spec, template, spec, volumes, {allIndexes: true}, secret]
+      nameKey: secretName
+      resourceMatchers: *builtinAppsControllers
+    - path: [spec, volumes, {allIndexes: true}, secret]
+      nameKey: secretName
+      resourceMatchers:
+      - apiVersionKindMatcher: {apiVersion: v1, kind: Pod}
+    - path: [spec, template, spec, volumes, {allIndexes: true}, projected, sources, {allIndexes: true}, secret]
+      resourceMatchers: *builtinAppsControllers
+    - path: [spec, volumes, {allIndexes: true}, projected, sources, {allIndexes: true}, secret]
+      resourceMatchers:
+      - apiVersionKindMatcher: {apiVersion: v1, kind: Pod}
 `
 
 var defaultConfigRes = ctlres.MustNewResourceFromBytes([]byte(defaultConfigYAML))
@@ -197,5 +189,52 @@ var defaultConfigRes = ctlres.MustNewResourceFromBytes([]byte(defaultConfigYAML)
 func NewDefaultConfigString() string { return defaultConfigYAML }
 
 func NewConfFromResourcesWithDefaults(resources []ctlres.Resource) ([]ctlres.Resource, Conf, error) {
-	return NewConfFromResources(append([]ctlres.Resource{defaultConfigRes}, resources...))
+	return NewConfFromResourcesWithSources(resources, ConfSources{})
+}
+
+// ConfSources specifies where config comes from besides config resources
+// found among deployed resources. Configs are applied in following order
+// (hence later ones take precedence where rules conflict, e.g. wait timeouts):
+// built-in config, cluster config, config files, deployed config resources.
+type ConfSources struct {
+	NoDefaults bool              // excludes built-in config
+	Cluster    []ctlres.Resource // from ConfigMap in state namespace
+	Files      []ctlres.Resource // from --config files
+}
+
+func NewConfFromResourcesWithSources(resources []ctlres.Resource, srcs ConfSources) ([]ctlres.Resource, Conf, error) {
+	var configs []Config
+
+	if !srcs.NoDefaults {
+		defaultConfig, err := NewConfigFromResource(defaultConfigRes)
+		if err != nil {
+			return nil, Conf{}, err
+		}
+
+		defaultConfig.desc = "built-in config"
+		configs = append(configs, defaultConfig)
+	}
+
+	for _, srcRs := range [][]ctlres.Resource{srcs.Cluster, srcs.Files} {
+		for _, res := range srcRs {
+			if !IsConfigResource(res) {
+				return nil, Conf{}, fmt.Errorf("Expected only config resources, but found '%s'%s",
+					res.Description(), configOriginDesc(res))
+			}
+		}
+
+		_, conf, err := NewConfFromResources(srcRs)
+		if err != nil {
+			return nil, Conf{}, err
+		}
+
+		configs = append(configs, conf.configs...)
+	}
+
+	rsWithoutConfigs, conf, err := NewConfFromResources(resources)
+	if err != nil {
+		return nil, Conf{}, err
+	}
+
+	return rsWithoutConfigs, Conf{append(configs, conf.configs...)}, nil
 }
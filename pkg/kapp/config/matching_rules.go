@@ -0,0 +1,227 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+)
+
+// MatchingRule describes config rule that affects particular resource
+type MatchingRule struct {
+	ConfigDesc string // example: built-in config
+	FieldPath  string // example: rebaseRules[2]
+	Summary    string // example: copy 'spec,clusterIP' from new, existing
+}
+
+func (r MatchingRule) String() string {
+	return fmt.Sprintf("%s (%s): %s", r.FieldPath, r.ConfigDesc, r.Summary)
+}
+
+// MatchingRules returns config rules that affect given resource
+// in the order they are applied (rules from later configs are applied last)
+func (c Conf) MatchingRules(res ctlres.Resource) []MatchingRule {
+	var result []MatchingRule
+
+	for _, config := range c.configs {
+		result = append(result, config.matchingRules(res)...)
+	}
+
+	return result
+}
+
+func (c Config) matchingRules(res ctlres.Resource) []MatchingRule {
+	var result []MatchingRule
+
+	add := func(fieldPath string, summary string, args ...interface{}) {
+		result = append(result, MatchingRule{
+			ConfigDesc: c.desc,
+			FieldPath:  fieldPath,
+			Summary:    fmt.Sprintf(summary, args...),
+		})
+	}
+
+	for i, rule := range c.AnnotationRules {
+		if ResourceMatchers(rule.ResourceMatchers).matches(res) {
+			var kvs []string
+			for _, k := range configSortedStringKeys(rule.Annotations) {
+				kvs = append(kvs, k+"="+rule.Annotations[k])
+			}
+			desc := "add annotations %s (unless already present)"
+			if rule.Override {
+				desc = "add annotations %s"
+			}
+			add(fmt.Sprintf("annotationRules[%d]", i), desc, strings.Join(kvs, ", "))
+		}
+	}
+
+	for i, rule := range c.OverrideRules {
+		if ResourceMatchers(rule.ResourceMatchers).matches(res) {
+			valBs, err := json.Marshal(rule.Value)
+			if err != nil {
+				valBs = []byte(fmt.Sprintf("%v", rule.Value))
+			}
+			add(fmt.Sprintf("overrideRules[%d]", i), "set '%s' to %s", rule.Path.AsString(), valBs)
+		}
+	}
+
+	for i, rule := range c.RebaseRules {
+		if ResourceMatchers(rule.ResourceMatchers).matches(res) {
+			fieldPath := fmt.Sprintf("rebaseRules[%d]", i)
+			switch rule.Type {
+			case rebaseRuleTypeCopy:
+				add(fieldPath, "copy '%s' from %s%s", rule.Path.AsString(), rule.sourcesString(), rule.conditionsString())
+			case rebaseRuleTypeMerge:
+				add(fieldPath, "merge '%s' from %s", rule.Path.AsString(), rule.sourcesString())
+			case rebaseRuleTypeRemove:
+				add(fieldPath, "remove '%s'", rule.Path.AsString())
+			default:
+				add(fieldPath, "unknown type '%s'", rule.Type)
+			}
+		}
+	}
+
+	for i, rule := range c.OwnershipLabelRules {
+		if ResourceMatchers(rule.ResourceMatchers).matches(res) {
+			add(fmt.Sprintf("ownershipLabelRules[%d]", i), "add app labels to '%s'", rule.Path.AsString())
+		}
+	}
+
+	for i, rule := range c.LabelScopingRules {
+		if ResourceMatchers(rule.ResourceMatchers).matches(res) {
+			add(fmt.Sprintf("labelScopingRules[%d]", i), "add app label to '%s' (if present)", rule.Path.AsString())
+		}
+	}
+
+	for i, rule := range c.TemplateRules {
+		fieldPath := fmt.Sprintf("templateRules[%d]", i)
+
+		if ResourceMatchers(rule.ResourceMatchers).matches(res) {
+			var paths []string
+			for _, objRef := range rule.AffectedResources.ObjectReferences {
+				paths = append(paths, objRef.summary())
+			}
+			add(fieldPath, "when versioned, update references to it in other resources at %s", strings.Join(paths, ", "))
+		}
+
+		for j, objRef := range rule.AffectedResources.ObjectReferences {
+			if ResourceMatchers(objRef.ResourceMatchers).matches(res) {
+				add(fmt.Sprintf("%s.affectedResources.objectReferences[%d]", fieldPath, j),
+					"update references at %s to versioned resources", objRef.summary())
+			}
+		}
+	}
+
+	for i, rule := range c.WaitRules {
+		if ResourceMatchers(rule.ResourceMatchers).matches(res) {
+			add(fmt.Sprintf("waitRules[%d]", i), "%s", rule.summary())
+		}
+	}
+
+	for i, rule := range c.OwnerReferenceAssociationRules {
+		if ResourceMatchers(rule.ResourceMatchers).matches(res) {
+			var types []string
+			for _, matcher := range rule.OwnedResourceTypes {
+				types = append(types, matcher.APIVersion+"/"+matcher.Kind)
+			}
+			add(fmt.Sprintf("ownerReferenceAssociationRules[%d]", i),
+				"associate owned resources of types %s", strings.Join(types, ", "))
+		}
+	}
+
+	for i, rule := range c.DiffAgainstLastAppliedFieldExclusionRules {
+		if ResourceMatchers(rule.ResourceMatchers).matches(res) {
+			add(fmt.Sprintf("diffAgainstLastAppliedFieldExclusionRules[%d]", i),
+				"ignore '%s' when diffing against last applied resource", rule.Path.AsString())
+		}
+	}
+
+	if len(c.AdditionalLabels) > 0 {
+		var kvs []string
+		for _, k := range configSortedStringKeys(c.AdditionalLabels) {
+			kvs = append(kvs, k+"="+c.AdditionalLabels[k])
+		}
+		add("additionalLabels", "add labels %s (via ownership label rules)", strings.Join(kvs, ", "))
+	}
+
+	return result
+}
+
+func (ms ResourceMatchers) matches(res ctlres.Resource) bool {
+	return (ctlres.AnyMatcher{ms.AsResourceMatchers()}).Matches(res)
+}
+
+func (r RebaseRule) sourcesString() string {
+	var srcs []string
+	for _, src := range r.Sources {
+		srcs = append(srcs, string(src))
+	}
+	return strings.Join(srcs, ", ")
+}
+
+func (r TemplateAffectedObjRef) summary() string {
+	if len(r.NameKey) > 0 {
+		return fmt.Sprintf("'%s' (via %s)", r.Path.AsString(), r.NameKey)
+	}
+	return "'" + r.Path.AsString() + "'"
+}
+
+func (r RebaseRule) conditionsString() string {
+	var conds []string
+	if r.IfNewEmpty {
+		conds = append(conds, "new is empty")
+	}
+	if len(r.IfExistingMatches) > 0 {
+		conds = append(conds, fmt.Sprintf("existing matches '%s'", r.IfExistingMatches))
+	}
+	if len(conds) == 0 {
+		return ""
+	}
+	return " (if " + strings.Join(conds, " and ") + ")"
+}
+
+func (r WaitRule) summary() string {
+	var pieces []string
+
+	if r.Timeout != nil {
+		pieces = append(pieces, fmt.Sprintf("timeout %s", r.Timeout.Duration))
+	}
+	if r.SupportsObservedGeneration {
+		pieces = append(pieces, "wait for observed generation")
+	}
+	for _, matcher := range r.ConditionMatchers {
+		pieces = append(pieces, fmt.Sprintf("condition %s=%s (%s)",
+			matcher.Type, matcher.Status, waitRuleOutcome(matcher.Success, matcher.Failure)))
+	}
+	for _, matcher := range r.FieldMatchers {
+		pieces = append(pieces, fmt.Sprintf("field '%s'=%s (%s)",
+			matcher.Path.AsString(), matcher.Value, waitRuleOutcome(matcher.Success, matcher.Failure)))
+	}
+	if r.ExternalCheck != nil {
+		pieces = append(pieces, fmt.Sprintf("external check '%s'", r.ExternalCheck.Command))
+	}
+
+	return strings.Join(pieces, "; ")
+}
+
+func waitRuleOutcome(success, failure bool) string {
+	switch {
+	case success:
+		return "success"
+	case failure:
+		return "failure"
+	default:
+		return "in progress"
+	}
+}
+
+func configSortedStringKeys(obj map[string]string) []string {
+	var keys []string
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,96 @@
+package config_test
+
+import (
+	"strings"
+	"testing"
+
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+)
+
+func TestConfMatchingRules(t *testing.T) {
+	configRes := ctlres.MustNewResourceFromBytes([]byte(`
+apiVersion: kapp.k14s.io/v1alpha1
+kind: Config
+overrideRules:
+- path: [spec, replicas]
+  value: 3
+  resourceMatchers:
+  - kindNamespaceNameMatcher: {kind: Deployment, namespace: "", name: app-web}
+rebaseRules:
+- path: [spec, replicas]
+  type: copy
+  sources: [existing, new]
+  resourceMatchers:
+  - apiVersionKindMatcher: {apiVersion: apps/v1, kind: Deployment}
+- path: [metadata, annotations, {keyGlob: "sidecar.istio.io/*"}]
+  type: copy
+  sources: [existing]
+  ifNewEmpty: true
+  resourceMatchers:
+  - allResourceMatcher: {}
+- path: [spec, template, metadata]
+  type: merge
+  sources: [existing]
+  resourceMatchers:
+  - allResourceMatcher: {}
+- path: [data]
+  type: remove
+  resourceMatchers:
+  - apiVersionKindMatcher: {apiVersion: v1, kind: ConfigMap}
+labelScopingRules:
+- path: [spec, selector, matchLabels]
+  resourceMatchers:
+  - notMatcher:
+      matcher:
+        nameMatcher: {name: other}
+templateRules:
+- resourceMatchers:
+  - apiVersionKindMatcher: {apiVersion: v1, kind: Secret}
+  affectedResources:
+    objectReferences:
+    - path: [spec, template, spec, volumes, {allIndexes: true}, secret]
+      nameKey: secretName
+      resourceMatchers:
+      - apiVersionKindMatcher: {apiVersion: apps/v1, kind: Deployment}
+waitRules:
+- resourceMatchers:
+  - nameMatcher: {name: app-*}
+  timeout: 5m
+  conditionMatchers:
+  - type: Available
+    status: "True"
+    success: true
+`))
+
+	res := ctlres.MustNewResourceFromBytes([]byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app-web
+`))
+
+	_, conf, err := ctlconf.NewConfFromResources([]ctlres.Resource{configRes})
+	if err != nil {
+		t.Fatalf("Expected no err, but was %s", err)
+	}
+
+	var result []string
+	for _, rule := range conf.MatchingRules(res) {
+		result = append(result, rule.FieldPath+": "+rule.Summary)
+	}
+
+	expected := strings.TrimSpace(`
+overrideRules[0]: set 'spec,replicas' to 3
+rebaseRules[0]: copy 'spec,replicas' from existing, new
+rebaseRules[1]: copy 'metadata,annotations,(keys sidecar.istio.io/*)' from existing (if new is empty)
+rebaseRules[2]: merge 'spec,template,metadata' from existing
+labelScopingRules[0]: add app label to 'spec,selector,matchLabels' (if present)
+templateRules[0].affectedResources.objectReferences[0]: update references at 'spec,template,spec,volumes,(all),secret' (via secretName) to versioned resources
+waitRules[0]: timeout 5m0s; condition Available=True (success)
+`)
+
+	if strings.Join(result, "\n") != expected {
+		t.Fatalf("Expected matching rules to be >>>%s<<<, but was >>>%s<<<", expected, strings.Join(result, "\n"))
+	}
+}
@@ -21,30 +21,10 @@ func (r stringMapAppendRule) AsMods(kvs map[string]string) []ctlres.StringMapApp
 }
 
 func (r stringMapAppendRule) singleMod(matcher ResourceMatcher, kvs map[string]string) ctlres.StringMapAppendMod {
-	mod := ctlres.StringMapAppendMod{
-		ResourceMatcher: ctlres.AllResourceMatcher{},
+	return ctlres.StringMapAppendMod{
+		ResourceMatcher: matcher.AsResourceMatcher(),
 		Path:            r.Path,
 		SkipIfNotFound:  r.SkipIfNotFound,
 		KVs:             kvs,
 	}
-
-	switch {
-	case matcher.KindNamespaceNameMatcher != nil:
-		mod.ResourceMatcher = ctlres.KindNamespaceNameMatcher{
-			Kind:      matcher.KindNamespaceNameMatcher.Kind,
-			Namespace: matcher.KindNamespaceNameMatcher.Namespace,
-			Name:      matcher.KindNamespaceNameMatcher.Name,
-		}
-
-	case matcher.APIVersionKindMatcher != nil:
-		mod.ResourceMatcher = ctlres.APIVersionKindMatcher{
-			APIVersion: matcher.APIVersionKindMatcher.APIVersion,
-			Kind:       matcher.APIVersionKindMatcher.Kind,
-		}
-
-	default:
-		mod.ResourceMatcher = ctlres.AllResourceMatcher{}
-	}
-
-	return mod
 }
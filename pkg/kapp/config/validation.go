@@ -0,0 +1,396 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	k8sval "k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	rebaseRuleTypeCopy   = "copy"
+	rebaseRuleTypeMerge  = "merge"
+	rebaseRuleTypeRemove = "remove"
+)
+
+// Validate checks config for mistakes that would otherwise
+// result in rules being silently ignored or misapplied
+func (c Config) Validate() []error {
+	var errs []error
+
+	for i, rule := range c.RebaseRules {
+		errs = append(errs, rule.validate(fmt.Sprintf("rebaseRules[%d]", i))...)
+	}
+	for i, rule := range c.OwnershipLabelRules {
+		errs = append(errs, stringMapAppendRule{ResourceMatchers: rule.ResourceMatchers, Path: rule.Path}.
+			validate(fmt.Sprintf("ownershipLabelRules[%d]", i))...)
+	}
+	for i, rule := range c.LabelScopingRules {
+		errs = append(errs, stringMapAppendRule{ResourceMatchers: rule.ResourceMatchers, Path: rule.Path}.
+			validate(fmt.Sprintf("labelScopingRules[%d]", i))...)
+	}
+	for i, rule := range c.TemplateRules {
+		errs = append(errs, rule.validate(fmt.Sprintf("templateRules[%d]", i))...)
+	}
+	for i, rule := range c.WaitRules {
+		errs = append(errs, rule.validate(fmt.Sprintf("waitRules[%d]", i))...)
+	}
+	for i, rule := range c.OwnerReferenceAssociationRules {
+		errs = append(errs, rule.validate(fmt.Sprintf("ownerReferenceAssociationRules[%d]", i))...)
+	}
+	for i, rule := range c.AnnotationRules {
+		errs = append(errs, rule.validate(fmt.Sprintf("annotationRules[%d]", i))...)
+	}
+	for i, rule := range c.OverrideRules {
+		errs = append(errs, rule.validate(fmt.Sprintf("overrideRules[%d]", i))...)
+	}
+	for i, rule := range c.DiffAgainstLastAppliedFieldExclusionRules {
+		fieldPath := fmt.Sprintf("diffAgainstLastAppliedFieldExclusionRules[%d]", i)
+		errs = append(errs, ResourceMatchers(rule.ResourceMatchers).validate(fieldPath+".resourceMatchers")...)
+		errs = append(errs, configPath(rule.Path).validate(fieldPath+".path", true)...)
+	}
+
+	return errs
+}
+
+func (r RebaseRule) validate(fieldPath string) []error {
+	errs := ResourceMatchers(r.ResourceMatchers).validate(fieldPath + ".resourceMatchers")
+	errs = append(errs, configPath(r.Path).validate(fieldPath+".path", true)...)
+
+	switch r.Type {
+	case rebaseRuleTypeCopy, rebaseRuleTypeMerge:
+		if len(r.Sources) == 0 {
+			errs = append(errs, fmt.Errorf("%s.sources: Expected at least one source for rebase rule of type '%s'", fieldPath, r.Type))
+		}
+		for i, src := range r.Sources {
+			if src != ctlres.FieldCopyModSourceNew && src != ctlres.FieldCopyModSourceExisting {
+				errs = append(errs, fmt.Errorf("%s.sources[%d]: Unknown source '%s' (supported: new, existing)", fieldPath, i, src))
+			}
+		}
+
+	case rebaseRuleTypeRemove:
+		if len(r.Sources) > 0 {
+			errs = append(errs, fmt.Errorf("%s.sources: Expected no sources for rebase rule of type 'remove'", fieldPath))
+		}
+
+	default:
+		errs = append(errs, r.unknownTypeErr(fieldPath))
+	}
+
+	if r.Type != rebaseRuleTypeCopy && (r.IfNewEmpty || len(r.IfExistingMatches) > 0) {
+		errs = append(errs, fmt.Errorf("%s: Expected conditions (ifNewEmpty, ifExistingMatches) "+
+			"only for rebase rule of type 'copy'", fieldPath))
+	}
+
+	if len(r.IfExistingMatches) > 0 {
+		// Regexp is matched against copied value hence it is only meaningful for existing values
+		if len(r.Sources) != 1 || r.Sources[0] != ctlres.FieldCopyModSourceExisting {
+			errs = append(errs, fmt.Errorf("%s.ifExistingMatches: Expected sources to only include 'existing'", fieldPath))
+		}
+		if _, err := regexp.Compile(r.IfExistingMatches); err != nil {
+			errs = append(errs, fmt.Errorf("%s.ifExistingMatches: Expected valid regexp: %s", fieldPath, err))
+		}
+	}
+
+	return errs
+}
+
+func (r RebaseRule) unknownTypeErr(fieldPath string) error {
+	return fmt.Errorf("%s.type: Unknown rebase rule type '%s' (supported: copy, merge, remove)", fieldPath, r.Type)
+}
+
+func (r stringMapAppendRule) validate(fieldPath string) []error {
+	errs := ResourceMatchers(r.ResourceMatchers).validate(fieldPath + ".resourceMatchers")
+	return append(errs, configPath(r.Path).validate(fieldPath+".path", false)...)
+}
+
+func (r AnnotationRule) validate(fieldPath string) []error {
+	errs := ResourceMatchers(r.ResourceMatchers).validate(fieldPath + ".resourceMatchers")
+
+	if len(r.Annotations) == 0 {
+		errs = append(errs, fmt.Errorf("%s.annotations: Expected at least one annotation", fieldPath))
+	}
+	for _, key := range configSortedStringKeys(r.Annotations) {
+		if errStrs := k8sval.IsQualifiedName(key); len(errStrs) > 0 {
+			errs = append(errs, fmt.Errorf("%s.annotations: Expected key '%s' to be a qualified name: %s",
+				fieldPath, key, strings.Join(errStrs, "; ")))
+		}
+	}
+
+	return errs
+}
+
+func (r OverrideRule) validate(fieldPath string) []error {
+	errs := ResourceMatchers(r.ResourceMatchers).validate(fieldPath + ".resourceMatchers")
+	errs = append(errs, configPath(r.Path).validate(fieldPath+".path", true)...)
+
+	if r.Value == nil {
+		errs = append(errs, fmt.Errorf("%s.value: Expected to be non-null", fieldPath))
+	}
+
+	return errs
+}
+
+func (r TemplateRule) validate(fieldPath string) []error {
+	errs := ResourceMatchers(r.ResourceMatchers).validate(fieldPath + ".resourceMatchers")
+
+	for i, objRef := range r.AffectedResources.ObjectReferences {
+		objRefPath := fmt.Sprintf("%s.affectedResources.objectReferences[%d]", fieldPath, i)
+		errs = append(errs, ResourceMatchers(objRef.ResourceMatchers).validate(objRefPath+".resourceMatchers")...)
+		errs = append(errs, configPath(objRef.Path).validate(objRefPath+".path", false)...)
+	}
+
+	return errs
+}
+
+func (r WaitRule) validate(fieldPath string) []error {
+	errs := ResourceMatchers(r.ResourceMatchers).validate(fieldPath + ".resourceMatchers")
+
+	for i, matcher := range r.ConditionMatchers {
+		matcherPath := fmt.Sprintf("%s.conditionMatchers[%d]", fieldPath, i)
+		if len(matcher.Type) == 0 {
+			errs = append(errs, fmt.Errorf("%s.type: Expected to be non-empty", matcherPath))
+		}
+		if matcher.Success && matcher.Failure {
+			errs = append(errs, fmt.Errorf("%s: Expected only one of success or failure to be set", matcherPath))
+		}
+	}
+
+	for i, matcher := range r.FieldMatchers {
+		matcherPath := fmt.Sprintf("%s.fieldMatchers[%d]", fieldPath, i)
+		errs = append(errs, configPath(matcher.Path).validate(matcherPath+".path", false)...)
+		if matcher.Success && matcher.Failure {
+			errs = append(errs, fmt.Errorf("%s: Expected only one of success or failure to be set", matcherPath))
+		}
+	}
+
+	if r.ExternalCheck != nil {
+		if len(r.ExternalCheck.Command) == 0 {
+			errs = append(errs, fmt.Errorf("%s.externalCheck.command: Expected to be non-empty", fieldPath))
+		}
+		// External check replaces other waiting behaviour, hence combining them is ambiguous
+		if r.SupportsObservedGeneration || len(r.ConditionMatchers) > 0 || len(r.FieldMatchers) > 0 {
+			errs = append(errs, fmt.Errorf("%s.externalCheck: Expected to not be used together with "+
+				"supportsObservedGeneration, conditionMatchers or fieldMatchers", fieldPath))
+		}
+	}
+
+	return errs
+}
+
+func (r OwnerReferenceAssociationRule) validate(fieldPath string) []error {
+	errs := ResourceMatchers(r.ResourceMatchers).validate(fieldPath + ".resourceMatchers")
+
+	if len(r.OwnedResourceTypes) == 0 {
+		errs = append(errs, fmt.Errorf("%s.ownedResourceTypes: Expected at least one type", fieldPath))
+	}
+	for i, resType := range r.OwnedResourceTypes {
+		errs = append(errs, resType.validate(fmt.Sprintf("%s.ownedResourceTypes[%d]", fieldPath, i))...)
+	}
+
+	return errs
+}
+
+func (ms ResourceMatchers) validate(fieldPath string) []error {
+	if len(ms) == 0 {
+		return []error{fmt.Errorf("%s: Expected at least one resource matcher", fieldPath)}
+	}
+
+	var errs []error
+	for i, matcher := range ms {
+		errs = append(errs, matcher.validate(fmt.Sprintf("%s[%d]", fieldPath, i))...)
+	}
+	return errs
+}
+
+func (m ResourceMatcher) validate(fieldPath string) []error {
+	var numSet int
+
+	for _, set := range []bool{m.AllResourceMatcher != nil, m.APIVersionKindMatcher != nil,
+		m.APIGroupKindMatcher != nil, m.KindNamespaceNameMatcher != nil, m.NameMatcher != nil,
+		m.NamespaceMatcher != nil, m.LabelSelectorMatcher != nil, m.AnnotationMatcher != nil,
+		m.AndMatcher != nil, m.OrMatcher != nil, m.NotMatcher != nil} {
+
+		if set {
+			numSet++
+		}
+	}
+
+	if numSet != 1 {
+		return []error{fmt.Errorf("%s: Expected exactly one matcher to be specified (e.g. allResourceMatcher, "+
+			"apiVersionKindMatcher, apiGroupKindMatcher), but found %d", fieldPath, numSet)}
+	}
+
+	switch {
+	case m.APIVersionKindMatcher != nil:
+		return m.APIVersionKindMatcher.validate(fieldPath + ".apiVersionKindMatcher")
+
+	case m.APIGroupKindMatcher != nil:
+		return configNonEmpty(fieldPath+".apiGroupKindMatcher.kind", m.APIGroupKindMatcher.Kind)
+
+	case m.KindNamespaceNameMatcher != nil:
+		return append(configNonEmpty(fieldPath+".kindNamespaceNameMatcher.kind", m.KindNamespaceNameMatcher.Kind),
+			configNonEmpty(fieldPath+".kindNamespaceNameMatcher.name", m.KindNamespaceNameMatcher.Name)...)
+
+	case m.NameMatcher != nil:
+		return configNonEmpty(fieldPath+".nameMatcher.name", m.NameMatcher.Name)
+
+	case m.LabelSelectorMatcher != nil:
+		// Empty selector would match all resources
+		return configNonEmpty(fieldPath+".labelSelectorMatcher.selector", strings.TrimSpace(m.LabelSelectorMatcher.Selector))
+
+	case m.AnnotationMatcher != nil:
+		return configNonEmpty(fieldPath+".annotationMatcher.key", m.AnnotationMatcher.Key)
+
+	case m.AndMatcher != nil:
+		return ResourceMatchers(m.AndMatcher.Matchers).validate(fieldPath + ".andMatcher.matchers")
+
+	case m.OrMatcher != nil:
+		return ResourceMatchers(m.OrMatcher.Matchers).validate(fieldPath + ".orMatcher.matchers")
+
+	case m.NotMatcher != nil:
+		return m.NotMatcher.Matcher.validate(fieldPath + ".notMatcher.matcher")
+
+	default:
+		return nil
+	}
+}
+
+func (m APIVersionKindMatcher) validate(fieldPath string) []error {
+	return append(configNonEmpty(fieldPath+".apiVersion", m.APIVersion),
+		configNonEmpty(fieldPath+".kind", m.Kind)...)
+}
+
+func configNonEmpty(fieldPath, val string) []error {
+	if len(val) == 0 {
+		return []error{fmt.Errorf("%s: Expected to be non-empty", fieldPath)}
+	}
+	return nil
+}
+
+type configPath ctlres.Path
+
+func (p configPath) validate(fieldPath string, lastPartMapKeys bool) []error {
+	if len(p) == 0 {
+		return []error{fmt.Errorf("%s: Expected to be non-empty", fieldPath)}
+	}
+
+	var errs []error
+
+	for i, part := range p {
+		switch {
+		case part == nil:
+			errs = append(errs, fmt.Errorf("%s[%d]: Expected to be non-null", fieldPath, i))
+		case part.ArrayIndex != nil && part.ArrayIndex.Index != nil && *part.ArrayIndex.Index < 0:
+			errs = append(errs, fmt.Errorf("%s[%d]: Expected index to be non-negative", fieldPath, i))
+		}
+	}
+
+	lastPart := p[len(p)-1]
+
+	switch {
+	case lastPart == nil:
+		// already reported
+	case lastPartMapKeys && lastPart.MapKey == nil && lastPart.MapKeys == nil:
+		errs = append(errs, fmt.Errorf("%s: Expected last path part to be a map key, allKeys or keyGlob", fieldPath))
+	case lastPart.Recursive != nil:
+		errs = append(errs, fmt.Errorf("%s: Expected recursive path part to be followed by other path parts", fieldPath))
+	}
+
+	return errs
+}
+
+var (
+	configJSONUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// configUnknownFields finds fields in unmarshaled YAML/JSON content
+// that do not correspond to any field of given type (since they
+// would be silently ignored during unmarshaling)
+func configUnknownFields(obj interface{}, typ reflect.Type, fieldPath string) []error {
+	// Types with custom unmarshaling are responsible for their own checks
+	if typ.Implements(configJSONUnmarshalerType) || reflect.PtrTo(typ).Implements(configJSONUnmarshalerType) {
+		return nil
+	}
+
+	var errs []error
+
+	switch typ.Kind() {
+	case reflect.Ptr:
+		return configUnknownFields(obj, typ.Elem(), fieldPath)
+
+	case reflect.Slice:
+		typedObj, ok := obj.([]interface{})
+		if !ok {
+			return nil // type mismatches are reported during unmarshaling
+		}
+		for i, item := range typedObj {
+			errs = append(errs, configUnknownFields(item, typ.Elem(), fmt.Sprintf("%s[%d]", fieldPath, i))...)
+		}
+
+	case reflect.Map:
+		typedObj, ok := obj.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		for _, key := range configSortedKeys(typedObj) {
+			errs = append(errs, configUnknownFields(typedObj[key], typ.Elem(), configFieldPath(fieldPath, key))...)
+		}
+
+	case reflect.Struct:
+		typedObj, ok := obj.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		fields := map[string]reflect.StructField{}
+
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if len(field.PkgPath) > 0 {
+				continue // unexported
+			}
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			if name == "-" {
+				continue
+			}
+			if len(name) == 0 {
+				name = field.Name
+			}
+			// Similar to encoding/json, keys are matched case-insensitively
+			fields[strings.ToLower(name)] = field
+		}
+
+		for _, key := range configSortedKeys(typedObj) {
+			field, found := fields[strings.ToLower(key)]
+			if !found {
+				errs = append(errs, fmt.Errorf("%s: Unknown field", configFieldPath(fieldPath, key)))
+				continue
+			}
+			errs = append(errs, configUnknownFields(typedObj[key], field.Type, configFieldPath(fieldPath, key))...)
+		}
+	}
+
+	return errs
+}
+
+func configFieldPath(fieldPath, key string) string {
+	if len(fieldPath) == 0 {
+		return key
+	}
+	return fieldPath + "." + key
+}
+
+func configSortedKeys(obj map[string]interface{}) []string {
+	var keys []string
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
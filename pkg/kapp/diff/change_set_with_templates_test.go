@@ -0,0 +1,110 @@
+package diff_test
+
+import (
+	"strings"
+	"testing"
+
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
+	ctldiff "github.com/k14s/kapp/pkg/kapp/diff"
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+)
+
+func TestChangeSetWithTemplates_UpdatesSecretVolumeReferences(t *testing.T) {
+	secretRes := ctlres.MustNewResourceFromBytes([]byte(`
+apiVersion: v1
+kind: Secret
+metadata:
+  name: creds
+  namespace: ns
+  annotations:
+    kapp.k14s.io/versioned: ""
+`))
+
+	depRes := ctlres.MustNewResourceFromBytes([]byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: ns
+spec:
+  template:
+    spec:
+      volumes:
+      - name: creds
+        secret:
+          secretName: creds
+      - name: projected
+        projected:
+          sources:
+          - secret:
+              name: creds
+      - name: other
+        secret:
+          secretName: other
+`))
+
+	podRes := ctlres.MustNewResourceFromBytes([]byte(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+  namespace: ns
+spec:
+  volumes:
+  - name: creds
+    secret:
+      secretName: creds
+`))
+
+	_, conf, err := ctlconf.NewConfFromResourcesWithDefaults(nil)
+	if err != nil {
+		t.Fatalf("Expected no err, but was %s", err)
+	}
+
+	changes, err := ctldiff.NewChangeSetWithTemplates(nil, []ctlres.Resource{secretRes, depRes, podRes},
+		conf.TemplateRules(), ctldiff.ChangeSetOpts{}, ctldiff.NewChangeFactory(nil, nil)).Calculate()
+	if err != nil {
+		t.Fatalf("Expected no err, but was %s", err)
+	}
+
+	results := map[string]string{}
+
+	for _, change := range changes {
+		bs, err := change.NewResource().AsYAMLBytes()
+		if err != nil {
+			t.Fatalf("Expected no err, but was %s", err)
+		}
+		results[change.NewResource().Kind()] = string(bs)
+	}
+
+	expectedDep := strings.TrimSpace(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: ns
+spec:
+  template:
+    spec:
+      volumes:
+      - name: creds
+        secret:
+          secretName: creds-ver-1
+      - name: projected
+        projected:
+          sources:
+          - secret:
+              name: creds-ver-1
+      - name: other
+        secret:
+          secretName: other
+`)
+
+	if strings.TrimSpace(results["Deployment"]) != expectedDep {
+		t.Fatalf("Expected deployment to be >>>%s<<<, but was >>>%s<<<", expectedDep, results["Deployment"])
+	}
+
+	if !strings.Contains(results["Pod"], "secretName: creds-ver-1") {
+		t.Fatalf("Expected pod secret volume reference to be updated, but was >>>%s<<<", results["Pod"])
+	}
+}
@@ -0,0 +1,144 @@
+package diff
+
+import (
+	"fmt"
+
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	// All versions of HorizontalPodAutoscaler have the same scaleTargetRef
+	HPAResourceTypes = []ctlres.APIGroupKindMatcher{
+		{APIGroup: "autoscaling", Kind: "HorizontalPodAutoscaler"},
+	}
+)
+
+// HPAReplicasRebase keeps existing replicas of resources targeted by
+// HorizontalPodAutoscalers since autoscalers continuously change them
+// (otherwise each deploy would reset replicas to what's in provided resources)
+type HPAReplicasRebase struct {
+	targets []hpaReplicasRebaseTarget
+}
+
+type hpaReplicasRebaseTarget struct {
+	matcher ctlres.ResourceMatcher
+	hpa     ctlres.Resource
+}
+
+// NewHPAReplicasRebase finds autoscalers among new resources and cluster resources.
+// Cluster autoscalers that are part of existing resources are only considered
+// if they are also part of new resources (otherwise they are about to be deleted).
+func NewHPAReplicasRebase(newRs, existingRs, clusterRs []ctlres.Resource) (HPAReplicasRebase, error) {
+	var hpas []ctlres.Resource
+	seenHPAs := map[string]struct{}{}
+
+	for _, res := range newRs {
+		if isHPA(res) {
+			hpas = append(hpas, res)
+			seenHPAs[ctlres.NewUniqueResourceKey(res).String()] = struct{}{}
+		}
+	}
+	for _, res := range existingRs {
+		seenHPAs[ctlres.NewUniqueResourceKey(res).String()] = struct{}{}
+	}
+	for _, res := range clusterRs {
+		if _, found := seenHPAs[ctlres.NewUniqueResourceKey(res).String()]; found {
+			continue
+		}
+		if isHPA(res) {
+			hpas = append(hpas, res)
+		}
+	}
+
+	var targets []hpaReplicasRebaseTarget
+
+	for _, hpa := range hpas {
+		var typedHPA autoscalingv1.HorizontalPodAutoscaler
+
+		err := hpa.AsUncheckedTypedObj(&typedHPA)
+		if err != nil {
+			return HPAReplicasRebase{}, fmt.Errorf("Converting %s: %s", hpa.Description(), err)
+		}
+
+		ref := typedHPA.Spec.ScaleTargetRef
+
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			return HPAReplicasRebase{}, fmt.Errorf("Parsing scaleTargetRef of %s: %s", hpa.Description(), err)
+		}
+
+		targets = append(targets, hpaReplicasRebaseTarget{
+			matcher: ctlres.AndMatcher{[]ctlres.ResourceMatcher{
+				ctlres.APIGroupKindMatcher{APIGroup: gv.Group, Kind: ref.Kind},
+				// Targets are always in the same namespace as autoscaler
+				ctlres.KindNamespaceNameMatcher{Kind: ref.Kind, Namespace: hpa.Namespace(), Name: ref.Name},
+			}},
+			hpa: hpa,
+		})
+	}
+
+	return HPAReplicasRebase{targets}, nil
+}
+
+// Mods are expected to be applied before config rebase
+// mods so that config is able to change this behaviour
+func (r HPAReplicasRebase) Mods() []ctlres.ResourceModWithMultiple {
+	var mods []ctlres.ResourceModWithMultiple
+	for _, target := range r.targets {
+		mods = append(mods, ctlres.FieldCopyMod{
+			ResourceMatcher: target.matcher,
+			Path:            ctlres.NewPathFromStrings([]string{"spec", "replicas"}),
+			Sources:         []ctlres.FieldCopyModSource{ctlres.FieldCopyModSourceExisting},
+		})
+	}
+	return mods
+}
+
+// Notes explain why replicas of given resource were rebased. Notes are
+// only included when replicas were actually changed to existing value
+// (config rebase rules may override it, or existing value may be missing).
+func (r HPAReplicasRebase) Notes(appliedRes, rebasedRes, existingRes ctlres.Resource) []string {
+	if appliedRes == nil || rebasedRes == nil || existingRes == nil {
+		return nil
+	}
+
+	rebasedReplicas, found := hpaReplicas(rebasedRes)
+	if !found {
+		return nil
+	}
+	if existingReplicas, found := hpaReplicas(existingRes); !found || existingReplicas != rebasedReplicas {
+		return nil
+	}
+	if appliedReplicas, found := hpaReplicas(appliedRes); found && appliedReplicas == rebasedReplicas {
+		return nil
+	}
+
+	var notes []string
+	for _, target := range r.targets {
+		if target.matcher.Matches(rebasedRes) {
+			notes = append(notes, fmt.Sprintf("spec.replicas rebased onto existing value since resource "+
+				"is scaled by horizontalpodautoscaler/%s", target.hpa.Name()))
+		}
+	}
+	return notes
+}
+
+// hpaReplicas returns string form of spec.replicas (if set)
+func hpaReplicas(res ctlres.Resource) (string, bool) {
+	vals, err := ctlres.NewPathFromStrings([]string{"spec", "replicas"}).FindValues(res.DeepCopyRaw())
+	if err != nil || len(vals) != 1 || vals[0] == nil {
+		return "", false
+	}
+	return fmt.Sprintf("%v", vals[0]), true
+}
+
+func isHPA(res ctlres.Resource) bool {
+	for _, matcher := range HPAResourceTypes {
+		if matcher.Matches(res) {
+			return true
+		}
+	}
+	return false
+}
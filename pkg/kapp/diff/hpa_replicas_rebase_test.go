@@ -0,0 +1,137 @@
+package diff_test
+
+import (
+	"strings"
+	"testing"
+
+	ctldiff "github.com/k14s/kapp/pkg/kapp/diff"
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+)
+
+func TestHPAReplicasRebase(t *testing.T) {
+	newHPA := ctlres.MustNewResourceFromBytes([]byte(`
+apiVersion: autoscaling/v1
+kind: HorizontalPodAutoscaler
+metadata:
+  name: web
+  namespace: ns
+spec:
+  scaleTargetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: web
+`))
+
+	clusterHPA := ctlres.MustNewResourceFromBytes([]byte(`
+apiVersion: autoscaling/v2beta1
+kind: HorizontalPodAutoscaler
+metadata:
+  name: worker-scaler
+  namespace: ns
+spec:
+  scaleTargetRef:
+    apiVersion: apps/v1
+    kind: StatefulSet
+    name: worker
+`))
+
+	// Part of the app in the cluster, but not part of new resources (i.e. deleted)
+	deletedHPA := ctlres.MustNewResourceFromBytes([]byte(`
+apiVersion: autoscaling/v1
+kind: HorizontalPodAutoscaler
+metadata:
+  name: api
+  namespace: ns
+spec:
+  scaleTargetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: api
+`))
+
+	newTarget := func(kind, name, ns, replicas string) ctlres.Resource {
+		return ctlres.MustNewResourceFromBytes([]byte(`
+apiVersion: apps/v1
+kind: ` + kind + `
+metadata:
+  name: ` + name + `
+  namespace: ` + ns + `
+spec:
+  replicas: ` + replicas))
+	}
+
+	rebase, err := ctldiff.NewHPAReplicasRebase([]ctlres.Resource{newHPA},
+		[]ctlres.Resource{deletedHPA}, []ctlres.Resource{clusterHPA, deletedHPA})
+	if err != nil {
+		t.Fatalf("Expected no err, but was %s", err)
+	}
+
+	rebased := func(newRes, existingRes ctlres.Resource) ctlres.Resource {
+		rebasedRes, err := ctldiff.NewRebasedResource(existingRes, newRes, rebase.Mods()).Resource()
+		if err != nil {
+			t.Fatalf("Expected no err, but was %s", err)
+		}
+		return rebasedRes
+	}
+
+	replicas := func(res ctlres.Resource) string {
+		bs, err := res.AsYAMLBytes()
+		if err != nil {
+			t.Fatalf("Expected no err, but was %s", err)
+		}
+		return strings.TrimSpace(strings.Split(string(bs), "replicas:")[1])
+	}
+
+	examples := []struct {
+		Description string
+		NewRes      ctlres.Resource
+		ExistingRes ctlres.Resource
+		Replicas    string
+		Notes       int
+	}{
+		{"target of new hpa", newTarget("Deployment", "web", "ns", "1"), newTarget("Deployment", "web", "ns", "5"), "5", 1},
+		{"target of deleted hpa", newTarget("Deployment", "api", "ns", "1"), newTarget("Deployment", "api", "ns", "5"), "1", 0},
+		{"same name in other namespace", newTarget("Deployment", "web", "other", "1"), newTarget("Deployment", "web", "other", "5"), "1", 0},
+		{"different kind", newTarget("StatefulSet", "web", "ns", "1"), newTarget("StatefulSet", "web", "ns", "5"), "1", 0},
+		{"target of cluster hpa", newTarget("StatefulSet", "worker", "ns", "1"), newTarget("StatefulSet", "worker", "ns", "3"), "3", 1},
+		{"same replicas as existing", newTarget("Deployment", "web", "ns", "5"), newTarget("Deployment", "web", "ns", "5"), "5", 0},
+	}
+
+	for _, ex := range examples {
+		rebasedRes := rebased(ex.NewRes, ex.ExistingRes)
+		if replicas := replicas(rebasedRes); replicas != ex.Replicas {
+			t.Fatalf("%s: Expected replicas to be %s, but was %s", ex.Description, ex.Replicas, replicas)
+		}
+		if notes := rebase.Notes(ex.NewRes, rebasedRes, ex.ExistingRes); len(notes) != ex.Notes {
+			t.Fatalf("%s: Expected %d notes, but was %#v", ex.Description, ex.Notes, notes)
+		}
+	}
+
+	// Existing resource without replicas
+	var existingRes ctlres.Resource = ctlres.MustNewResourceFromBytes([]byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: ns
+`))
+	newRes := newTarget("Deployment", "web", "ns", "1")
+
+	if notes := rebase.Notes(newRes, rebased(newRes, existingRes), existingRes); len(notes) != 0 {
+		t.Fatalf("Expected no notes when existing resource has no replicas, but was %#v", notes)
+	}
+
+	// Config rebase rule kept new replicas
+	if notes := rebase.Notes(newRes, newRes, newTarget("Deployment", "web", "ns", "5")); len(notes) != 0 {
+		t.Fatalf("Expected no notes when replicas were not rebased, but was %#v", notes)
+	}
+
+	expectedNote := "spec.replicas rebased onto existing value since resource is scaled by horizontalpodautoscaler/worker-scaler"
+
+	newRes = newTarget("StatefulSet", "worker", "ns", "1")
+	existingRes = newTarget("StatefulSet", "worker", "ns", "3")
+
+	if notes := rebase.Notes(newRes, rebased(newRes, existingRes), existingRes); notes[0] != expectedNote {
+		t.Fatalf("Expected note to be >>>%s<<<, but was >>>%s<<<", expectedNote, notes[0])
+	}
+}
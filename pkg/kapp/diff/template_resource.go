@@ -95,6 +95,7 @@ func (d TemplateResource) buildObjRefReplacementFunc(
 	affectedObjRef ctlconf.TemplateAffectedObjRef) func(map[string]interface{}) error {
 
 	nonTemplatedName, _ := d.NonTemplatedName()
+	nameKey := affectedObjRef.NameKeyOrDefault()
 
 	return func(typedObj map[string]interface{}) error {
 		bs, err := json.Marshal(typedObj)
@@ -109,8 +110,9 @@ func (d TemplateResource) buildObjRefReplacementFunc(
 			return fmt.Errorf("Unmarshaling object reference: %s", err)
 		}
 
-		// Check as many rules as possible
-		if objRef.Name != nonTemplatedName {
+		// Name may be kept under a different key (e.g. secretName)
+		// while other keys (if present) are still checked
+		if name, _ := typedObj[nameKey].(string); name != nonTemplatedName {
 			return nil
 		}
 		if len(objRef.Namespace) > 0 && objRef.Namespace != d.res.Namespace() {
@@ -123,7 +125,7 @@ func (d TemplateResource) buildObjRefReplacementFunc(
 			return nil
 		}
 
-		typedObj["name"] = d.res.Name()
+		typedObj[nameKey] = d.res.Name()
 
 		return nil
 	}
@@ -68,3 +68,7 @@ func (r ChangeRule) Validate() error {
 	}
 	return nil
 }
+
+func (r ChangeRule) String() string {
+	return fmt.Sprintf("%s %s %s %s", r.Action, r.Order, r.TargetAction, r.TargetGroup.Name)
+}
@@ -105,6 +105,7 @@ func (l PodContainerLog) obtainStream(ui ui.UI, linePrefix string, cancelCh chan
 			logs := l.podsClient.GetLogs(l.pod.Name, &corev1.PodLogOptions{
 				Follow:    l.opts.Follow,
 				TailLines: l.opts.Lines,
+				Previous:  l.opts.Previous,
 				Container: l.container,
 				// TODO other options
 			})
@@ -11,6 +11,7 @@ import (
 type PodLogOpts struct {
 	Follow       bool
 	Lines        *int64
+	Previous     bool // show logs of previously terminated container
 	ContainerTag bool
 	LinePrefix   string
 }
@@ -19,6 +19,13 @@ func NewStringMatcher(expected string) StringMatcher {
 }
 
 func (f StringMatcher) Matches(actual string) bool {
+	switch {
+	case len(f.expected) == 0:
+		return len(actual) == 0
+	case len(f.expected) == 1 && (f.expected[0] == stringMatcherGlob1 || f.expected[0] == stringMatcherGlob2):
+		return true // single glob char matches everything
+	}
+
 	firstChar := f.expected[0]
 	lastChar := f.expected[len(f.expected)-1]
 
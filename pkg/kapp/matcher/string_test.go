@@ -29,6 +29,11 @@ func TestStringMatcherMatches(t *testing.T) {
 		{Expected: "*app*", Actual: "extra-pp", Result: false},
 		{Expected: "*app*", Actual: "extra-app-extra", Result: true},
 		{Expected: "*app*", Actual: "extra-ap-extra", Result: false},
+
+		{Expected: "*", Actual: "app", Result: true},
+		{Expected: "*", Actual: "", Result: true},
+		{Expected: "", Actual: "", Result: true},
+		{Expected: "", Actual: "app", Result: false},
 	}
 
 	for _, ex := range exs {
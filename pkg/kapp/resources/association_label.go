@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 )
 
 const (
@@ -32,3 +33,23 @@ func (a AssociationLabel) Value() string { return a.v1Value() }
 func (a AssociationLabel) AsSelector() labels.Selector {
 	return labels.Set(map[string]string{kappAssociationLabelKey: a.v1Value()}).AsSelector()
 }
+
+// Matches returns true if given resource is labeled as associated with this label's resource
+func (a AssociationLabel) Matches(res Resource) bool {
+	return res.Labels()[kappAssociationLabelKey] == a.v1Value()
+}
+
+// NewAssociationLabelsSelector selects resources associated with any of given resources
+func NewAssociationLabelsSelector(resources []Resource) (labels.Selector, error) {
+	var vals []string
+	for _, res := range resources {
+		vals = append(vals, NewAssociationLabel(res).v1Value())
+	}
+
+	req, err := labels.NewRequirement(kappAssociationLabelKey, selection.In, vals)
+	if err != nil {
+		return nil, err
+	}
+
+	return labels.NewSelector().Add(*req), nil
+}
@@ -0,0 +1,132 @@
+package resources
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// WarningEvents returns warning events in given namespace (oldest first)
+func (r IdentifiedResources) WarningEvents(namespace string) ([]corev1.Event, error) {
+	defer r.logger.DebugFunc(fmt.Sprintf("WarningEvents(%s)", namespace)).Finish()
+
+	fieldSelector := fields.Set{"type": corev1.EventTypeWarning}
+
+	eventsList, err := r.coreClient.CoreV1().Events(namespace).List(
+		metav1.ListOptions{FieldSelector: fieldSelector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	events := eventsList.Items
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return EventLastSeen(events[i]).Before(EventLastSeen(events[j]))
+	})
+
+	return events, nil
+}
+
+// Events returns events in given namespace (oldest first) and
+// resource version that could be used to watch for newer events
+func (r IdentifiedResources) Events(namespace string) ([]corev1.Event, string, error) {
+	defer r.logger.DebugFunc(fmt.Sprintf("Events(%s)", namespace)).Finish()
+
+	eventsList, err := r.coreClient.CoreV1().Events(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, "", err
+	}
+
+	events := eventsList.Items
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return EventLastSeen(events[i]).Before(EventLastSeen(events[j]))
+	})
+
+	return events, eventsList.ResourceVersion, nil
+}
+
+// WatchEvents sends events in given namespace as they are added or updated
+// after given resource version. Blocks until cancelCh is closed or watching fails.
+func (r IdentifiedResources) WatchEvents(namespace, resourceVersion string,
+	eventsCh chan corev1.Event, cancelCh chan struct{}) error {
+
+	defer r.logger.DebugFunc(fmt.Sprintf("WatchEvents(%s)", namespace)).Finish()
+
+	for {
+		watcher, err := r.coreClient.CoreV1().Events(namespace).Watch(
+			metav1.ListOptions{ResourceVersion: resourceVersion})
+		if err != nil {
+			return fmt.Errorf("Creating watcher for events: %s", err)
+		}
+
+		resourceVersion, err = r.watchEvents(namespace, watcher, resourceVersion, eventsCh, cancelCh)
+		watcher.Stop()
+
+		if err != nil || len(resourceVersion) == 0 {
+			return err
+		}
+	}
+}
+
+// watchEvents returns resource version to continue watching from
+// or empty string if watching should stop
+func (r IdentifiedResources) watchEvents(namespace string, watcher watch.Interface, resourceVersion string,
+	eventsCh chan corev1.Event, cancelCh chan struct{}) (string, error) {
+
+	for {
+		select {
+		case e, ok := <-watcher.ResultChan():
+			if !ok || e.Object == nil {
+				// Watcher may expire, hence continue from last seen event
+				return resourceVersion, nil
+			}
+
+			if e.Type == watch.Error {
+				// Resource version may be too old, hence start from
+				// latest events (some events may not be seen)
+				_, latestResourceVersion, err := r.Events(namespace)
+				if err != nil {
+					return "", err
+				}
+				return latestResourceVersion, nil
+			}
+
+			event, ok := e.Object.(*corev1.Event)
+			if !ok {
+				continue
+			}
+
+			resourceVersion = event.ResourceVersion
+
+			if e.Type == watch.Added || e.Type == watch.Modified {
+				select {
+				case eventsCh <- *event:
+				case <-cancelCh:
+					return "", nil
+				}
+			}
+
+		case <-cancelCh:
+			return "", nil
+		}
+	}
+}
+
+func EventLastSeen(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.FirstTimestamp.Time
+	}
+}
@@ -11,6 +11,117 @@ import (
 func (r IdentifiedResources) List(labelSelector labels.Selector) ([]Resource, error) {
 	defer r.logger.DebugFunc("List").Finish()
 
+	resTypes, err := r.listableResourceTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	allOpts := ResourcesAllOpts{
+		ListOpts: &metav1.ListOptions{
+			LabelSelector: labelSelector.String(),
+		},
+	}
+
+	return r.listAll(resTypes, allOpts)
+}
+
+// ListOwned returns resources that are owned (directly or indirectly, up to maxDepth levels)
+// by given resources via ownerReferences. Given resources are not included in the result.
+// Useful to find resources created by controllers that do not propagate labels.
+func (r IdentifiedResources) ListOwned(owners []Resource, maxDepth int) ([]Resource, error) {
+	defer r.logger.DebugFunc("ListOwned").Finish()
+
+	if len(owners) == 0 || maxDepth <= 0 {
+		return nil, nil
+	}
+
+	resTypes, err := r.listableResourceTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.listOwned(owners, resTypes, maxDepth)
+}
+
+// ListOwnedOfTypes is similar to ListOwned but only lists resources of given types
+// (intermediate owners have to be of given types as well). Since it avoids listing
+// all resource types, it is cheap enough to be called repeatedly (e.g. while waiting).
+func (r IdentifiedResources) ListOwnedOfTypes(owners []Resource,
+	ownedTypes []APIVersionKindMatcher, maxDepth int) ([]Resource, error) {
+
+	defer r.logger.DebugFunc("ListOwnedOfTypes").Finish()
+
+	if len(owners) == 0 || len(ownedTypes) == 0 || maxDepth <= 0 {
+		return nil, nil
+	}
+
+	resTypes, err := r.listableResourceTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.listOwned(owners, MatchingAPIVersionKinds(resTypes, ownedTypes), maxDepth)
+}
+
+// ListOfTypes lists all resources (including ones not created by kapp)
+// of given types within given namespaces (e.g. to find autoscalers)
+func (r IdentifiedResources) ListOfTypes(types []APIGroupKindMatcher, namespaces []string) ([]Resource, error) {
+	defer r.logger.DebugFunc("ListOfTypes").Finish()
+
+	if len(types) == 0 || len(namespaces) == 0 {
+		return nil, nil
+	}
+
+	resTypes, err := r.listableResourceTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.listAll(MatchingAPIGroupKinds(resTypes, types), ResourcesAllOpts{Namespaces: namespaces})
+}
+
+func (r IdentifiedResources) listOwned(owners []Resource, resTypes []ResourceType, maxDepth int) ([]Resource, error) {
+	var namespaces []string
+	var hasClusterOwners bool
+	seenNamespaces := map[string]struct{}{}
+
+	for _, owner := range owners {
+		ns := owner.Namespace()
+		if len(ns) == 0 {
+			hasClusterOwners = true
+			continue
+		}
+		if _, found := seenNamespaces[ns]; !found {
+			seenNamespaces[ns] = struct{}{}
+			namespaces = append(namespaces, ns)
+		}
+	}
+
+	// Cluster level resources can only be owned by other cluster level resources,
+	// and namespaced resources can only be owned by resources in the same namespace
+	// or by cluster level resources, hence avoid listing unrelated namespaces
+	allOpts := ResourcesAllOpts{}
+
+	if !hasClusterOwners {
+		var namespacedTypes []ResourceType
+		for _, resType := range resTypes {
+			if resType.Namespaced() {
+				namespacedTypes = append(namespacedTypes, resType)
+			}
+		}
+		resTypes = namespacedTypes
+		allOpts.Namespaces = namespaces
+	}
+
+	allResources, err := r.listAll(resTypes, allOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewOwnedResources(owners, maxDepth).Select(allResources), nil
+}
+
+func (r IdentifiedResources) listableResourceTypes() ([]ResourceType, error) {
 	resTypes, err := r.resourceTypes.All()
 	if err != nil {
 		return nil, err
@@ -29,12 +140,10 @@ func (r IdentifiedResources) List(labelSelector labels.Selector) ([]Resource, er
 		schema.GroupVersionResource{Version: "v1", Resource: "componentstatuses"},
 	})
 
-	allOpts := ResourcesAllOpts{
-		ListOpts: &metav1.ListOptions{
-			LabelSelector: labelSelector.String(),
-		},
-	}
+	return resTypes, nil
+}
 
+func (r IdentifiedResources) listAll(resTypes []ResourceType, allOpts ResourcesAllOpts) ([]Resource, error) {
 	resources, err := r.resources.All(resTypes, allOpts)
 	if err != nil {
 		return nil, err
@@ -7,12 +7,17 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 )
 
 func (r IdentifiedResources) PodResources(labelSelector labels.Selector) UniquePodWatcher {
 	return UniquePodWatcher{labelSelector, r.coreClient}
 }
 
+func (r IdentifiedResources) PodsClient(namespace string) typedcorev1.PodInterface {
+	return r.coreClient.CoreV1().Pods(namespace)
+}
+
 type PodWatcherI interface {
 	Watch(podsToWatchCh chan corev1.Pod, cancelCh chan struct{}) error
 }
@@ -0,0 +1,244 @@
+package resources
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+const (
+	// Re-watching is delayed when watches end without any events
+	// (e.g. API server closes them right away) to avoid busy looping
+	watchRetryMinInterval = 500 * time.Millisecond
+	watchRetryMaxInterval = 30 * time.Second
+)
+
+var (
+	// Resource types that commonly carry association labels of their parents
+	// (e.g. Pods and ReplicaSets created from Deployment templates)
+	commonAssociatedResourceRefs = []ResourceRef{
+		{schema.GroupVersionResource{Version: "v1", Resource: "pods"}},
+		{schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "replicasets"}},
+	}
+)
+
+// WatchAssociated sends resources (of the same types as given resources, plus Pods and ReplicaSets)
+// that are associated with any of given resources whenever they are added, modified or deleted.
+// Resources are selected by association label, hence resources that are not labeled
+// (e.g. owned via ownerReferences or related resources) are not sent.
+// Blocks until cancelCh is closed or watching fails.
+func (r IdentifiedResources) WatchAssociated(resources []Resource,
+	resourcesCh chan Resource, cancelCh chan struct{}) error {
+
+	defer r.logger.DebugFunc("WatchAssociated").Finish()
+
+	if len(resources) == 0 {
+		return nil
+	}
+
+	resTypes, err := r.associatedResourceTypes(resources)
+	if err != nil {
+		return err
+	}
+
+	labelSelector, err := NewAssociationLabelsSelector(resources)
+	if err != nil {
+		return err
+	}
+
+	listOpts := metav1.ListOptions{LabelSelector: labelSelector.String()}
+
+	return r.watchTypes(resTypes, listOpts, func(event ResourceEvent) bool {
+		select {
+		case resourcesCh <- event.Resource:
+			return true
+		case <-cancelCh:
+			return false
+		}
+	}, cancelCh)
+}
+
+type ResourceEvent struct {
+	Type     watch.EventType // Added, Modified or Deleted
+	Resource Resource
+}
+
+// WatchLabeled sends events for resources (of the same types as given resources, plus Pods and ReplicaSets)
+// that match label selector. Similar to List, resources not created by kapp are marked as transient.
+// Blocks until cancelCh is closed or watching fails.
+func (r IdentifiedResources) WatchLabeled(labelSelector labels.Selector, resources []Resource,
+	eventsCh chan ResourceEvent, cancelCh chan struct{}) error {
+
+	defer r.logger.DebugFunc("WatchLabeled").Finish()
+
+	resTypes, err := r.associatedResourceTypes(resources)
+	if err != nil {
+		return err
+	}
+
+	listOpts := metav1.ListOptions{LabelSelector: labelSelector.String()}
+
+	return r.watchTypes(resTypes, listOpts, func(event ResourceEvent) bool {
+		idAnn := NewIdentityAnnotation(event.Resource)
+		if idAnn.Valid() {
+			err := idAnn.RemoveMod().Apply(event.Resource)
+			if err != nil {
+				return true // skip unexpected resource
+			}
+		} else {
+			event.Resource.MarkTransient(true)
+		}
+
+		select {
+		case eventsCh <- event:
+			return true
+		case <-cancelCh:
+			return false
+		}
+	}, cancelCh)
+}
+
+func (r IdentifiedResources) watchTypes(resTypes []ResourceType, listOpts metav1.ListOptions,
+	eventFunc func(ResourceEvent) bool, cancelCh chan struct{}) error {
+
+	errsCh := make(chan error, len(resTypes))
+
+	for _, resType := range resTypes {
+		resType := resType // copy
+
+		go func() {
+			errsCh <- r.watchTypeWithRetries(resType, listOpts, eventFunc, cancelCh)
+		}()
+	}
+
+	for i := 0; i < len(resTypes); i++ {
+		err := <-errsCh
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r IdentifiedResources) associatedResourceTypes(resources []Resource) ([]ResourceType, error) {
+	allTypes, err := r.resourceTypes.All()
+	if err != nil {
+		return nil, err
+	}
+
+	var resTypes []ResourceType
+	seenTypes := map[string]struct{}{}
+
+	addType := func(resType ResourceType) {
+		key := resType.GroupVersionResource.String()
+		if _, found := seenTypes[key]; !found {
+			seenTypes[key] = struct{}{}
+			resTypes = append(resTypes, resType)
+		}
+	}
+
+	for _, ref := range commonAssociatedResourceRefs {
+		for _, resType := range Matching(allTypes, ref) {
+			addType(resType)
+		}
+	}
+
+	for _, res := range resources {
+		resType, err := r.resourceTypes.Find(res)
+		if err != nil {
+			return nil, err
+		}
+		addType(resType)
+	}
+
+	return resTypes, nil
+}
+
+// watchTypeWithRetries re-watches after watcher expires, continuing from
+// last seen resource version so that events are not missed or repeated
+func (r IdentifiedResources) watchTypeWithRetries(resType ResourceType, listOpts metav1.ListOptions,
+	eventFunc func(ResourceEvent) bool, cancelCh chan struct{}) error {
+
+	retryInterval := watchRetryMinInterval
+
+	for {
+		resourceVersion, seenEvents, retry, err := r.watchType(resType, listOpts, eventFunc, cancelCh)
+		if err != nil || !retry {
+			return err
+		}
+
+		listOpts.ResourceVersion = resourceVersion
+
+		if seenEvents {
+			retryInterval = watchRetryMinInterval
+			continue
+		}
+
+		select {
+		case <-time.After(retryInterval):
+		case <-cancelCh:
+			return nil
+		}
+
+		retryInterval *= 2
+		if retryInterval > watchRetryMaxInterval {
+			retryInterval = watchRetryMaxInterval
+		}
+	}
+}
+
+// watchType returns resource version to continue watching from,
+// whether any events were seen and whether watching should be retried
+func (r IdentifiedResources) watchType(resType ResourceType, listOpts metav1.ListOptions,
+	eventFunc func(ResourceEvent) bool, cancelCh chan struct{}) (string, bool, bool, error) {
+
+	watcher, err := r.resources.Watch(resType, listOpts)
+	if err != nil {
+		return "", false, false, fmt.Errorf("Creating watcher for %s: %s", resType.GroupVersionResource, err)
+	}
+
+	defer watcher.Stop()
+
+	resourceVersion := listOpts.ResourceVersion
+	seenEvents := false
+
+	for {
+		select {
+		case e, ok := <-watcher.ResultChan():
+			if !ok || e.Object == nil {
+				// Watcher may expire, hence retry from last seen resource version
+				return resourceVersion, seenEvents, true, nil
+			}
+
+			if e.Type == watch.Error {
+				// Resource version may be too old, hence start over
+				// (resources are sent again as added)
+				return "", seenEvents, true, nil
+			}
+
+			item, ok := e.Object.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+
+			resourceVersion = item.GetResourceVersion()
+			seenEvents = true
+
+			switch e.Type {
+			case watch.Added, watch.Modified, watch.Deleted:
+				if !eventFunc(ResourceEvent{e.Type, NewResourceUnstructured(*item, resType)}) {
+					return "", seenEvents, false, nil
+				}
+			}
+
+		case <-cancelCh:
+			return "", seenEvents, false, nil
+		}
+	}
+}
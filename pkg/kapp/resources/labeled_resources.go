@@ -11,6 +11,9 @@ import (
 
 const (
 	disableLabelScopingAnnKey = "kapp.k14s.io/disable-label-scoping" // valid value is ''
+
+	// Limits how far ownership chains are followed for associated resources
+	associatedOwnedResourcesMaxDepth = 5
 )
 
 type OwnershipLabelModsFunc func(kvs map[string]string) []StringMapAppendMod
@@ -57,13 +60,12 @@ func (a *LabeledResources) Prepare(resources []Resource, olmFunc OwnershipLabelM
 		}
 
 		// Scope labels on all resources except ones that explicitly opt out
-		disableLSVal, disableLabelScoping := res.Annotations()[disableLabelScopingAnnKey]
-		if disableLabelScoping {
-			if disableLSVal != "" {
-				return fmt.Errorf("Expected annotation '%s' on resource '%s' to have value ''",
-					disableLabelScopingAnnKey, res.Description())
-			}
-		} else {
+		disableLabelScoping, err := IsLabelScopingDisabled(res)
+		if err != nil {
+			return err
+		}
+
+		if !disableLabelScoping {
 			for _, t := range lsmFunc(map[string]string{labelKey: labelVal}) {
 				err := t.Apply(res)
 				if err != nil {
@@ -76,11 +78,53 @@ func (a *LabeledResources) Prepare(resources []Resource, olmFunc OwnershipLabelM
 	return nil
 }
 
+// IsLabelScopingDisabled returns true if resource opts out of label scoping
+func IsLabelScopingDisabled(res Resource) (bool, error) {
+	val, found := res.Annotations()[disableLabelScopingAnnKey]
+	if found && val != "" {
+		return false, fmt.Errorf("Expected annotation '%s' on resource '%s' to have value ''",
+			disableLabelScopingAnnKey, res.Description())
+	}
+	return found, nil
+}
+
 func (a *LabeledResources) GetAssociated(resource Resource) ([]Resource, error) {
 	defer a.logger.DebugFunc("GetAssociated").Finish()
 	return a.identifiedResources.List(NewAssociationLabel(resource).AsSelector())
 }
 
+// GetAssociatedAndOwned additionally includes resources of given types that are
+// owned by resource via ownerReferences (e.g. resources created by custom
+// controllers that do not propagate association label)
+func (a *LabeledResources) GetAssociatedAndOwned(resource Resource, ownedTypes []APIVersionKindMatcher) ([]Resource, error) {
+	defer a.logger.DebugFunc("GetAssociatedAndOwned").Finish()
+
+	associatedRs, err := a.identifiedResources.List(NewAssociationLabel(resource).AsSelector())
+	if err != nil {
+		return nil, err
+	}
+
+	ownedRs, err := a.identifiedResources.ListOwnedOfTypes(
+		[]Resource{resource}, ownedTypes, associatedOwnedResourcesMaxDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	seenUIDs := map[string]struct{}{}
+
+	for _, res := range associatedRs {
+		seenUIDs[res.UID()] = struct{}{}
+	}
+
+	for _, res := range ownedRs {
+		if _, found := seenUIDs[res.UID()]; !found {
+			associatedRs = append(associatedRs, res)
+		}
+	}
+
+	return associatedRs, nil
+}
+
 func (a *LabeledResources) All() ([]Resource, error) {
 	defer a.logger.DebugFunc("All").Finish()
 
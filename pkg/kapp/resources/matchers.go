@@ -1,5 +1,10 @@
 package resources
 
+import (
+	"github.com/k14s/kapp/pkg/kapp/matcher"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
 type ResourceMatcher interface {
 	Matches(Resource) bool
 }
@@ -19,6 +24,31 @@ func (m AnyMatcher) Matches(res Resource) bool {
 	return false
 }
 
+type AndMatcher struct {
+	Matchers []ResourceMatcher
+}
+
+var _ ResourceMatcher = AndMatcher{}
+
+func (m AndMatcher) Matches(res Resource) bool {
+	for _, matcher := range m.Matchers {
+		if !matcher.Matches(res) {
+			return false
+		}
+	}
+	return true
+}
+
+type NotMatcher struct {
+	Matcher ResourceMatcher
+}
+
+var _ ResourceMatcher = NotMatcher{}
+
+func (m NotMatcher) Matches(res Resource) bool {
+	return !m.Matcher.Matches(res)
+}
+
 type APIGroupKindMatcher struct {
 	APIGroup string
 	Kind     string
@@ -51,6 +81,56 @@ func (m KindNamespaceNameMatcher) Matches(res Resource) bool {
 	return res.Kind() == m.Kind && res.Namespace() == m.Namespace && res.Name() == m.Name
 }
 
+// NameMatcher supports globs (example: 'app-*')
+type NameMatcher struct {
+	Name string
+}
+
+var _ ResourceMatcher = NameMatcher{}
+
+func (m NameMatcher) Matches(res Resource) bool {
+	return matcher.NewStringMatcher(m.Name).Matches(res.Name())
+}
+
+// NamespaceMatcher supports globs (example: 'team-*');
+// cluster level resources have empty namespace
+type NamespaceMatcher struct {
+	Namespace string
+}
+
+var _ ResourceMatcher = NamespaceMatcher{}
+
+func (m NamespaceMatcher) Matches(res Resource) bool {
+	return matcher.NewStringMatcher(m.Namespace).Matches(res.Namespace())
+}
+
+type LabelSelectorMatcher struct {
+	Selector labels.Selector
+}
+
+var _ ResourceMatcher = LabelSelectorMatcher{}
+
+func (m LabelSelectorMatcher) Matches(res Resource) bool {
+	return m.Selector.Matches(labels.Set(res.Labels()))
+}
+
+// AnnotationMatcher matches resources that have annotation
+// (with given value, if value is specified)
+type AnnotationMatcher struct {
+	Key   string
+	Value *string
+}
+
+var _ ResourceMatcher = AnnotationMatcher{}
+
+func (m AnnotationMatcher) Matches(res Resource) bool {
+	val, found := res.Annotations()[m.Key]
+	if !found {
+		return false
+	}
+	return m.Value == nil || *m.Value == val
+}
+
 type AllResourceMatcher struct{}
 
 var _ ResourceMatcher = AllResourceMatcher{}
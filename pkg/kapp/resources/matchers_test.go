@@ -0,0 +1,66 @@
+package resources_test
+
+import (
+	"testing"
+
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestMatchers(t *testing.T) {
+	res := ctlres.MustNewResourceFromBytes([]byte(`
+apiVersion: apps/v1beta2
+kind: Deployment
+metadata:
+  name: app-web
+  namespace: team-a
+  labels:
+    tier: web
+  annotations:
+    example.com/owner: team-a
+`))
+
+	annVal := "team-a"
+	otherAnnVal := "team-b"
+
+	exs := []struct {
+		Description string
+		Matcher     ctlres.ResourceMatcher
+		Result      bool
+	}{
+		{"api group kind", ctlres.APIGroupKindMatcher{APIGroup: "apps", Kind: "Deployment"}, true},
+		{"api group kind (other group)", ctlres.APIGroupKindMatcher{APIGroup: "extensions", Kind: "Deployment"}, false},
+		{"name glob", ctlres.NameMatcher{Name: "app-*"}, true},
+		{"name glob (no match)", ctlres.NameMatcher{Name: "*-db"}, false},
+		{"namespace glob", ctlres.NamespaceMatcher{Namespace: "team-*"}, true},
+		{"namespace (no match)", ctlres.NamespaceMatcher{Namespace: "team-b"}, false},
+		{"label selector", ctlres.LabelSelectorMatcher{Selector: labels.SelectorFromSet(labels.Set{"tier": "web"})}, true},
+		{"label selector (no match)", ctlres.LabelSelectorMatcher{Selector: labels.SelectorFromSet(labels.Set{"tier": "db"})}, false},
+		{"annotation key", ctlres.AnnotationMatcher{Key: "example.com/owner"}, true},
+		{"annotation key and value", ctlres.AnnotationMatcher{Key: "example.com/owner", Value: &annVal}, true},
+		{"annotation key and value (no match)", ctlres.AnnotationMatcher{Key: "example.com/owner", Value: &otherAnnVal}, false},
+		{"annotation key (no match)", ctlres.AnnotationMatcher{Key: "example.com/other"}, false},
+		{
+			"and",
+			ctlres.AndMatcher{[]ctlres.ResourceMatcher{
+				ctlres.APIGroupKindMatcher{APIGroup: "apps", Kind: "Deployment"},
+				ctlres.NotMatcher{ctlres.NamespaceMatcher{Namespace: "kube-*"}},
+			}},
+			true,
+		},
+		{
+			"and (no match)",
+			ctlres.AndMatcher{[]ctlres.ResourceMatcher{
+				ctlres.APIGroupKindMatcher{APIGroup: "apps", Kind: "Deployment"},
+				ctlres.NotMatcher{ctlres.NamespaceMatcher{Namespace: "team-*"}},
+			}},
+			false,
+		},
+	}
+
+	for _, ex := range exs {
+		if ex.Matcher.Matches(res) != ex.Result {
+			t.Fatalf("%s: expected match result to be %t", ex.Description, ex.Result)
+		}
+	}
+}
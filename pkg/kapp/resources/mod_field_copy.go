@@ -2,6 +2,7 @@ package resources
 
 import (
 	"fmt"
+	"regexp"
 )
 
 type FieldCopyModSource string
@@ -15,6 +16,10 @@ type FieldCopyMod struct {
 	ResourceMatcher ResourceMatcher
 	Path            Path
 	Sources         []FieldCopyModSource // first preferred
+
+	// Optional conditions checked for each copied location
+	IfDestinationEmpty bool           // e.g. copy existing value only if new value is empty
+	IfSourceMatches    *regexp.Regexp // matched against string form of source value
 }
 
 var _ ResourceModWithMultiple = FieldCopyMod{}
@@ -28,7 +33,7 @@ func (t FieldCopyMod) ApplyFromMultiple(res Resource, srcs map[FieldCopyModSourc
 	// that may be done even in case when there is nothing to copy
 	updatedRes := res.DeepCopy()
 
-	updated, err := t.apply(updatedRes.unstructured().Object, t.Path, Path{}, srcs)
+	updated, err := t.apply(updatedRes.unstructured().Object, srcs)
 	if err != nil {
 		return fmt.Errorf("FieldCopyMod for path '%s' on resource '%s': %s", t.Path.AsString(), res.Description(), err)
 	}
@@ -40,95 +45,84 @@ func (t FieldCopyMod) ApplyFromMultiple(res Resource, srcs map[FieldCopyModSourc
 	return nil
 }
 
-func (t FieldCopyMod) apply(obj interface{}, path Path, fullPath Path, srcs map[FieldCopyModSource]Resource) (bool, error) {
-	for i, part := range path {
-		isLast := len(path) == i+1
-		fullPath = append(fullPath, part)
+func (t FieldCopyMod) apply(obj interface{}, srcs map[FieldCopyModSource]Resource) (bool, error) {
+	if len(t.Path) == 0 {
+		return false, fmt.Errorf("Expected path to be non-empty")
+	}
 
-		switch {
-		case part.MapKey != nil:
-			typedObj, ok := obj.(map[string]interface{})
-			if !ok {
-				return false, fmt.Errorf("Unexpected non-map found: %T", obj)
-			}
+	lastPart := t.Path[len(t.Path)-1]
 
-			if isLast {
-				return t.copyIntoMap(typedObj, fullPath, srcs)
-			}
+	walker := pathWalker{createMissingMaps: true}
 
-			var found bool
-			obj, found = typedObj[*part.MapKey]
-			// TODO check strictness?
-			if !found || obj == nil {
-				// create empty maps if there are no downstream array indexes;
-				// if there are, we cannot make them anyway, so just exit
-				if path.ContainsNonMapKeys() {
-					return false, nil
-				}
-				obj = map[string]interface{}{}
-				typedObj[*part.MapKey] = obj
-			}
+	var anyUpdated bool
 
-		case part.ArrayIndex != nil:
-			if isLast {
-				panic("Expected last part of the path to be map key")
+	err := walker.WalkParents(obj, t.Path, func(obj interface{}, fullPath Path) error {
+		typedObj, ok := obj.(map[string]interface{})
+		if !ok {
+			if obj == nil {
+				return nil // nothing to copy into
 			}
+			return pathUnexpectedTypeErr("map", obj, fullPath)
+		}
 
-			switch {
-			case part.ArrayIndex.All != nil:
-				typedObj, ok := obj.([]interface{})
-				if !ok {
-					return false, fmt.Errorf("Unexpected non-array found: %T", obj)
-				}
-
-				var anyUpdated bool
-
-				for objI, obj := range typedObj {
-					objI := objI
+		updated, err := t.copyIntoMap(typedObj, fullPath, lastPart, srcs)
+		if updated {
+			anyUpdated = true
+		}
+		return err
+	})
 
-					newFullPath := append([]*PathPart{}, fullPath...)
-					newFullPath[len(newFullPath)-1] = &PathPart{ArrayIndex: &PathPartArrayIndex{Index: &objI}}
+	return anyUpdated, err
+}
 
-					updated, err := t.apply(obj, path[i+1:], newFullPath, srcs)
-					if err != nil {
-						return false, err
-					}
-					if updated {
-						anyUpdated = true
-					}
-				}
+func (t FieldCopyMod) copyIntoMap(obj map[string]interface{}, fullPath Path,
+	lastPart *PathPart, srcs map[FieldCopyModSource]Resource) (bool, error) {
 
-				return anyUpdated, nil // dealt with children, get out
+	// Keys matched by a pattern may be found in destination or any of the sources
+	keyObjs := []map[string]interface{}{obj}
 
-			case part.ArrayIndex.Index != nil:
-				typedObj, ok := obj.([]interface{})
-				if !ok {
-					return false, fmt.Errorf("Unexpected non-array found: %T", obj)
-				}
+	if lastPart.MapKeys != nil {
+		for _, src := range t.Sources {
+			srcRes, found := srcs[src]
+			if !found || srcRes == nil {
+				continue
+			}
 
-				if *part.ArrayIndex.Index < len(typedObj) {
-					obj = typedObj[*part.ArrayIndex.Index]
-					return t.apply(obj, path[i+1:], fullPath, srcs)
-				}
+			srcObj, found, err := pathObtainValue(srcRes.unstructured().Object, fullPath)
+			if err != nil {
+				return false, err
+			}
+			if typedSrcObj, ok := srcObj.(map[string]interface{}); found && ok {
+				keyObjs = append(keyObjs, typedSrcObj)
+			}
+		}
+	}
 
-				return false, nil // index not found, nothing to append to
+	keys, err := pathLastPartMapKeys(lastPart, keyObjs...)
+	if err != nil {
+		return false, err
+	}
 
-			default:
-				panic(fmt.Sprintf("Unknown array index: %#v", part.ArrayIndex))
-			}
+	var anyUpdated bool
 
-		default:
-			panic(fmt.Sprintf("Unexpected path part: %#v", part))
+	for _, key := range keys {
+		updated, err := t.copyKeyIntoMap(obj, append(append(Path{}, fullPath...), NewPathPartFromString(key)), srcs)
+		if err != nil {
+			return false, err
+		}
+		if updated {
+			anyUpdated = true
 		}
 	}
 
-	panic("unreachable")
+	return anyUpdated, nil
 }
 
-func (t FieldCopyMod) copyIntoMap(obj map[string]interface{}, fullPath Path, srcs map[FieldCopyModSource]Resource) (bool, error) {
+func (t FieldCopyMod) copyKeyIntoMap(obj map[string]interface{}, fullPath Path, srcs map[FieldCopyModSource]Resource) (bool, error) {
 	lastPartPath := fullPath[len(fullPath)-1]
-	if lastPartPath.MapKey == nil {
-		return false, fmt.Errorf("Expected last path part to be map-key")
+
+	if t.IfDestinationEmpty && !fieldValueEmpty(obj[*lastPartPath.MapKey]) {
+		return false, nil
 	}
 
 	for _, src := range t.Sources {
@@ -137,13 +131,17 @@ func (t FieldCopyMod) copyIntoMap(obj map[string]interface{}, fullPath Path, src
 			continue
 		}
 
-		val, found, err := t.obtainValue(srcRes.unstructured().Object, fullPath)
+		val, found, err := pathObtainValue(srcRes.unstructured().Object, fullPath)
 		if err != nil {
 			return false, err
 		} else if !found {
 			continue
 		}
 
+		if t.IfSourceMatches != nil && !t.IfSourceMatches.MatchString(fmt.Sprintf("%v", val)) {
+			continue
+		}
+
 		obj[*lastPartPath.MapKey] = val
 		return true, nil
 	}
@@ -151,49 +149,18 @@ func (t FieldCopyMod) copyIntoMap(obj map[string]interface{}, fullPath Path, src
 	return false, nil
 }
 
-func (t FieldCopyMod) obtainValue(obj interface{}, path Path) (interface{}, bool, error) {
-	for i, part := range path {
-		isLast := len(path) == i+1
-
-		switch {
-		case part.MapKey != nil:
-			typedObj, ok := obj.(map[string]interface{})
-			if !ok {
-				return nil, false, fmt.Errorf("Unexpected non-map found: %T", obj)
-			}
-
-			var found bool
-			obj, found = typedObj[*part.MapKey]
-			if !found {
-				return nil, false, nil // index is not found return
-			}
-
-		case part.ArrayIndex != nil:
-			if isLast {
-				return nil, false, fmt.Errorf("Expected last part of the path to be map key")
-			}
-
-			switch {
-			case part.ArrayIndex.Index != nil:
-				typedObj, ok := obj.([]interface{})
-				if !ok {
-					return nil, false, fmt.Errorf("Unexpected non-array found: %T", obj)
-				}
-
-				if *part.ArrayIndex.Index < len(typedObj) {
-					obj = typedObj[*part.ArrayIndex.Index]
-				} else {
-					return nil, false, nil // index not found, return
-				}
-
-			default:
-				panic(fmt.Sprintf("Unknown array index: %#v", part.ArrayIndex))
-			}
-
-		default:
-			panic(fmt.Sprintf("Unexpected path part: %#v", part))
-		}
+// fieldValueEmpty returns true for missing, null and zero length values
+func fieldValueEmpty(val interface{}) bool {
+	switch typedVal := val.(type) {
+	case nil:
+		return true
+	case string:
+		return len(typedVal) == 0
+	case map[string]interface{}:
+		return len(typedVal) == 0
+	case []interface{}:
+		return len(typedVal) == 0
+	default:
+		return false
 	}
-
-	return obj, true, nil
 }
@@ -1,6 +1,7 @@
 package resources_test
 
 import (
+	"regexp"
 	"testing"
 
 	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
@@ -195,6 +196,169 @@ metadata:
   - label-key: existing-label-val
   - label-key: another-existing-label-val`,
 		},
+		{
+			Description: "copies value from array element matched by field regardless of its position",
+			Res: `
+spec:
+  containers:
+  - name: sidecar
+  - name: app`,
+			Expected: `
+spec:
+  containers:
+  - name: sidecar
+  - image: existing-image
+    name: app`,
+			Sources: []ctlres.FieldCopyModSource{ctlres.FieldCopyModSourceNew, ctlres.FieldCopyModSourceExisting},
+			Path: ctlres.Path{
+				ctlres.NewPathPartFromString("spec"),
+				ctlres.NewPathPartFromString("containers"),
+				ctlres.NewPathPartFromMatchField("name", "app"),
+				ctlres.NewPathPartFromString("image"),
+			},
+			NewRes: `
+spec:
+  containers:
+  - name: sidecar
+  - name: app`,
+			ExistingRes: `
+spec:
+  containers:
+  - name: app
+    image: existing-image
+  - name: sidecar
+    image: sidecar-image`,
+		},
+		{
+			Description: "copies keys matching glob from new or existing",
+			Res: `
+metadata:
+  annotations:
+    other: val`,
+			Expected: `
+metadata:
+  annotations:
+    other: val
+    sidecar.istio.io/inject: "false"
+    sidecar.istio.io/status: existing-status`,
+			Sources: []ctlres.FieldCopyModSource{ctlres.FieldCopyModSourceNew, ctlres.FieldCopyModSourceExisting},
+			Path: ctlres.Path{
+				ctlres.NewPathPartFromString("metadata"),
+				ctlres.NewPathPartFromString("annotations"),
+				ctlres.NewPathPartFromKeyGlob("sidecar.istio.io/*"),
+			},
+			NewRes: `
+metadata:
+  annotations:
+    sidecar.istio.io/inject: "false"`,
+			ExistingRes: `
+metadata:
+  annotations:
+    other: existing-val
+    sidecar.istio.io/inject: "true"
+    sidecar.istio.io/status: existing-status`,
+		},
+		{
+			Description: "copies values found at any depth",
+			Res: `
+spec:
+  template:
+    spec:
+      nodeName: null`,
+			Expected: `
+spec:
+  nodeName: top-node
+  template:
+    spec:
+      nodeName: nested-node`,
+			Sources: []ctlres.FieldCopyModSource{ctlres.FieldCopyModSourceExisting},
+			Path: ctlres.Path{
+				ctlres.NewPathPartRecursive(),
+				ctlres.NewPathPartFromString("nodeName"),
+			},
+			ExistingRes: `
+spec:
+  nodeName: top-node
+  template:
+    spec:
+      nodeName: nested-node`,
+		},
+		{
+			Description: "does not create missing maps below recursive path part",
+			Res: `
+spec:
+  template:
+    metadata: {}`,
+			Expected: `
+spec:
+  template:
+    metadata:
+      labels:
+        app: app`,
+			Sources: []ctlres.FieldCopyModSource{ctlres.FieldCopyModSourceExisting},
+			Path: ctlres.Path{
+				ctlres.NewPathPartRecursive(),
+				ctlres.NewPathPartFromString("metadata"),
+				ctlres.NewPathPartFromString("labels"),
+			},
+			ExistingRes: `
+metadata:
+  labels:
+    app: app
+spec:
+  template:
+    metadata:
+      labels:
+        app: app`,
+		},
+		{
+			Description: "copies from existing only when destination is empty",
+			Res: `
+metadata:
+  annotations:
+    sidecar.istio.io/inject: "false"
+    sidecar.istio.io/status: ""`,
+			Expected: `
+metadata:
+  annotations:
+    sidecar.istio.io/inject: "false"
+    sidecar.istio.io/rewriteAppHTTPProbers: "true"
+    sidecar.istio.io/status: existing-status`,
+			Sources: []ctlres.FieldCopyModSource{ctlres.FieldCopyModSourceExisting},
+			Path: ctlres.Path{
+				ctlres.NewPathPartFromString("metadata"),
+				ctlres.NewPathPartFromString("annotations"),
+				ctlres.NewPathPartFromKeyGlob("sidecar.istio.io/*"),
+			},
+			IfDestinationEmpty: true,
+			ExistingRes: `
+metadata:
+  annotations:
+    sidecar.istio.io/inject: "true"
+    sidecar.istio.io/rewriteAppHTTPProbers: "true"
+    sidecar.istio.io/status: existing-status`,
+		},
+		{
+			Description: "copies from existing only when source matches",
+			Res: `
+spec:
+  clusterIP: ""
+  loadBalancerIP: ""`,
+			Expected: `
+spec:
+  clusterIP: 10.0.0.1
+  loadBalancerIP: ""`,
+			Sources: []ctlres.FieldCopyModSource{ctlres.FieldCopyModSourceExisting},
+			Path: ctlres.Path{
+				ctlres.NewPathPartFromString("spec"),
+				ctlres.NewPathPartFromKeysAll(),
+			},
+			IfSourceMatches: regexp.MustCompile(`^10\.`),
+			ExistingRes: `
+spec:
+  clusterIP: 10.0.0.1
+  loadBalancerIP: 35.0.0.1`,
+		},
 	}
 
 	for _, ex := range exs {
@@ -210,6 +374,9 @@ type modFieldCopyExample struct {
 	NewRes      string
 	ExistingRes string
 	Expected    string
+
+	IfDestinationEmpty bool
+	IfSourceMatches    *regexp.Regexp
 }
 
 func (e modFieldCopyExample) Check(t *testing.T) {
@@ -227,6 +394,9 @@ func (e modFieldCopyExample) Check(t *testing.T) {
 		ResourceMatcher: ctlres.AllResourceMatcher{},
 		Path:            e.Path,
 		Sources:         e.Sources,
+
+		IfDestinationEmpty: e.IfDestinationEmpty,
+		IfSourceMatches:    e.IfSourceMatches,
 	}.ApplyFromMultiple(res, ress)
 	if err != nil {
 		t.Fatalf("Expected no err, but was %s", err)
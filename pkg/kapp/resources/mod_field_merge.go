@@ -0,0 +1,143 @@
+package resources
+
+import (
+	"fmt"
+)
+
+// FieldMergeMod deep merges maps found in sources into resource
+// (values already present in resource are kept, hence
+// only missing map keys are added at any depth)
+type FieldMergeMod struct {
+	ResourceMatcher ResourceMatcher
+	Path            Path
+	Sources         []FieldCopyModSource // first preferred
+}
+
+var _ ResourceModWithMultiple = FieldMergeMod{}
+
+func (t FieldMergeMod) ApplyFromMultiple(res Resource, srcs map[FieldCopyModSource]Resource) error {
+	if res == nil || !t.ResourceMatcher.Matches(res) {
+		return nil
+	}
+
+	// Make a copy of resource, to avoid modifications
+	// that may be done even in case when there is nothing to merge
+	updatedRes := res.DeepCopy()
+
+	updated, err := t.apply(updatedRes.unstructured().Object, srcs)
+	if err != nil {
+		return fmt.Errorf("FieldMergeMod for path '%s' on resource '%s': %s", t.Path.AsString(), res.Description(), err)
+	}
+
+	if updated {
+		res.setUnstructured(updatedRes.unstructured())
+	}
+
+	return nil
+}
+
+func (t FieldMergeMod) apply(obj interface{}, srcs map[FieldCopyModSource]Resource) (bool, error) {
+	if len(t.Path) == 0 {
+		return false, fmt.Errorf("Expected path to be non-empty")
+	}
+
+	lastPart := t.Path[len(t.Path)-1]
+
+	walker := pathWalker{createMissingMaps: true}
+
+	var anyUpdated bool
+
+	err := walker.WalkParents(obj, t.Path, func(obj interface{}, fullPath Path) error {
+		typedObj, ok := obj.(map[string]interface{})
+		if !ok {
+			if obj == nil {
+				return nil // nothing to merge into
+			}
+			return pathUnexpectedTypeErr("map", obj, fullPath)
+		}
+
+		updated, err := t.mergeIntoMap(typedObj, fullPath, lastPart, srcs)
+		if updated {
+			anyUpdated = true
+		}
+		return err
+	})
+
+	return anyUpdated, err
+}
+
+func (t FieldMergeMod) mergeIntoMap(obj map[string]interface{}, fullPath Path,
+	lastPart *PathPart, srcs map[FieldCopyModSource]Resource) (bool, error) {
+
+	var srcObjs []map[string]interface{}
+
+	for _, src := range t.Sources {
+		srcRes, found := srcs[src]
+		if !found || srcRes == nil {
+			continue
+		}
+
+		srcObj, found, err := pathObtainValue(srcRes.unstructured().Object, fullPath)
+		if err != nil {
+			return false, err
+		}
+		if typedSrcObj, ok := srcObj.(map[string]interface{}); found && ok {
+			srcObjs = append(srcObjs, typedSrcObj)
+		}
+	}
+
+	// Keys matched by a pattern may be found in destination or any of the sources
+	keys, err := pathLastPartMapKeys(lastPart, append([]map[string]interface{}{obj}, srcObjs...)...)
+	if err != nil {
+		return false, err
+	}
+
+	var anyUpdated bool
+
+	for _, key := range keys {
+		for _, srcObj := range srcObjs {
+			srcVal, found := srcObj[key]
+			if !found {
+				continue
+			}
+
+			val, updated := t.mergeValues(obj[key], srcVal)
+			if updated {
+				obj[key] = val
+				anyUpdated = true
+			}
+		}
+	}
+
+	return anyUpdated, nil
+}
+
+// mergeValues returns destination value with missing map keys added from
+// source value (non-map destination values are not modified unless null)
+func (t FieldMergeMod) mergeValues(dst, src interface{}) (interface{}, bool) {
+	if dst == nil {
+		return src, src != nil
+	}
+
+	typedDst, ok := dst.(map[string]interface{})
+	if !ok {
+		return dst, false
+	}
+
+	typedSrc, ok := src.(map[string]interface{})
+	if !ok {
+		return dst, false
+	}
+
+	var anyUpdated bool
+
+	for key, srcVal := range typedSrc {
+		val, updated := t.mergeValues(typedDst[key], srcVal)
+		if updated {
+			typedDst[key] = val
+			anyUpdated = true
+		}
+	}
+
+	return typedDst, anyUpdated
+}
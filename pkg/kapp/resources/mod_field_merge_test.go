@@ -0,0 +1,134 @@
+package resources_test
+
+import (
+	"testing"
+
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+)
+
+func TestModFieldMerge(t *testing.T) {
+	exs := []modFieldMergeExample{
+		{
+			Description: "adds keys missing in resource at any depth",
+			Res: `
+metadata:
+  annotations:
+    app-ann: new-val
+spec:
+  template:
+    metadata:
+      annotations:
+        app-ann: new-val`,
+			Expected: `
+metadata:
+  annotations:
+    app-ann: new-val
+spec:
+  template:
+    metadata:
+      annotations:
+        app-ann: new-val
+        injected-ann: existing-val
+      labels:
+        injected-label: existing-val`,
+			Path: ctlres.NewPathFromStrings([]string{"spec", "template"}),
+			ExistingRes: `
+metadata:
+  annotations:
+    app-ann: existing-val
+    injected-ann: existing-val
+spec:
+  template:
+    metadata:
+      annotations:
+        app-ann: existing-val
+        injected-ann: existing-val
+      labels:
+        injected-label: existing-val`,
+		},
+		{
+			Description: "keeps non-map values in resource",
+			Res: `
+spec:
+  selector: app
+  ports: []`,
+			Expected: `
+spec:
+  ports: []
+  selector: app`,
+			Path: ctlres.NewPathFromStrings([]string{"spec"}),
+			ExistingRes: `
+spec:
+  selector:
+    app: app
+  ports:
+  - port: 80`,
+		},
+		{
+			Description: "merges keys matching glob",
+			Res: `
+metadata: {}`,
+			Expected: `
+metadata:
+  annotations:
+    other: existing-val`,
+			Path: ctlres.Path{
+				ctlres.NewPathPartFromString("metadata"),
+				ctlres.NewPathPartFromKeysAll(),
+			},
+			ExistingRes: `
+metadata:
+  annotations:
+    other: existing-val`,
+		},
+		{
+			Description: "leaves resource unmodified when nothing to merge",
+			Res: `
+metadata:
+  labels: null`,
+			Expected: `
+metadata:
+  labels: null`,
+			Path: ctlres.NewPathFromStrings([]string{"metadata", "labels", "label-key"}),
+			ExistingRes: `
+metadata: {}`,
+		},
+	}
+
+	for _, ex := range exs {
+		ex.Check(t)
+	}
+}
+
+type modFieldMergeExample struct {
+	Description string
+	Res         string
+	Path        ctlres.Path
+	ExistingRes string
+	Expected    string
+}
+
+func (e modFieldMergeExample) Check(t *testing.T) {
+	res := ctlres.MustNewResourceFromBytes([]byte(e.Res))
+
+	ress := map[ctlres.FieldCopyModSource]ctlres.Resource{
+		ctlres.FieldCopyModSourceNew:      res.DeepCopy(),
+		ctlres.FieldCopyModSourceExisting: ctlres.MustNewResourceFromBytes([]byte(e.ExistingRes)),
+	}
+
+	err := ctlres.FieldMergeMod{
+		ResourceMatcher: ctlres.AllResourceMatcher{},
+		Path:            e.Path,
+		Sources:         []ctlres.FieldCopyModSource{ctlres.FieldCopyModSourceExisting},
+	}.ApplyFromMultiple(res, ress)
+	if err != nil {
+		t.Fatalf("Expected no err, but was %s", err)
+	}
+
+	resultBs, err := res.AsYAMLBytes()
+	if err != nil {
+		t.Fatalf("Expected no err, but was %s", err)
+	}
+
+	expectEqualsStripped(t, e.Description, string(resultBs), e.Expected)
+}
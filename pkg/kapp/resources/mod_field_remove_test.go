@@ -104,6 +104,90 @@ metadata:
 				ctlres.NewPathPartFromString("label-key"),
 			},
 		},
+		{
+			Description: "deleting keys matching glob",
+			Res: `
+metadata:
+  annotations:
+    other: val
+    sidecar.istio.io/inject: "true"
+    sidecar.istio.io/status: status`,
+			Expected: `
+metadata:
+  annotations:
+    other: val`,
+			Path: ctlres.Path{
+				ctlres.NewPathPartFromString("metadata"),
+				ctlres.NewPathPartFromString("annotations"),
+				ctlres.NewPathPartFromKeyGlob("sidecar.istio.io/*"),
+			},
+		},
+		{
+			Description: "deleting key under every key of a map",
+			Res: `
+data:
+  a:
+    secret: val
+    other: val
+  b:
+    secret: val`,
+			Expected: `
+data:
+  a:
+    other: val
+  b: {}`,
+			Path: ctlres.Path{
+				ctlres.NewPathPartFromString("data"),
+				ctlres.NewPathPartFromKeysAll(),
+				ctlres.NewPathPartFromString("secret"),
+			},
+		},
+		{
+			Description: "deleting leaf key under array element matched by field",
+			Res: `
+spec:
+  containers:
+  - name: app
+    image: app-image
+  - name: sidecar
+    image: sidecar-image`,
+			Expected: `
+spec:
+  containers:
+  - name: app
+  - image: sidecar-image
+    name: sidecar`,
+			Path: ctlres.Path{
+				ctlres.NewPathPartFromString("spec"),
+				ctlres.NewPathPartFromString("containers"),
+				ctlres.NewPathPartFromMatchField("name", "app"),
+				ctlres.NewPathPartFromString("image"),
+			},
+		},
+		{
+			Description: "deleting key at any depth",
+			Res: `
+metadata:
+  creationTimestamp: null
+spec:
+  template:
+    metadata:
+      creationTimestamp: null
+  items:
+  - creationTimestamp: null
+    name: item`,
+			Expected: `
+metadata: {}
+spec:
+  items:
+  - name: item
+  template:
+    metadata: {}`,
+			Path: ctlres.Path{
+				ctlres.NewPathPartRecursive(),
+				ctlres.NewPathPartFromString("creationTimestamp"),
+			},
+		},
 	}
 
 	for _, ex := range exs {
@@ -111,6 +195,42 @@ metadata:
 	}
 }
 
+func TestModFieldRemoveErrs(t *testing.T) {
+	res := ctlres.MustNewResourceFromBytes([]byte(`
+spec:
+  containers: {}`))
+
+	err := ctlres.FieldRemoveMod{
+		ResourceMatcher: ctlres.AllResourceMatcher{},
+		Path: ctlres.Path{
+			ctlres.NewPathPartFromString("spec"),
+			ctlres.NewPathPartFromString("containers"),
+			ctlres.NewPathPartFromIndexAll(),
+			ctlres.NewPathPartFromString("image"),
+		},
+	}.Apply(res)
+	if err == nil {
+		t.Fatalf("Expected err")
+	}
+
+	expectEquals(t, "non-array error", err.Error(), "FieldRemoveMod for path 'spec,containers,(all),image' "+
+		"on resource '/ () cluster': Expected array at path 'spec,containers', but found map[string]interface {}")
+
+	err = ctlres.FieldRemoveMod{
+		ResourceMatcher: ctlres.AllResourceMatcher{},
+		Path: ctlres.Path{
+			ctlres.NewPathPartFromString("spec"),
+			ctlres.NewPathPartFromIndexAll(),
+		},
+	}.Apply(res)
+	if err == nil {
+		t.Fatalf("Expected err")
+	}
+
+	expectEquals(t, "last part error", err.Error(), "FieldRemoveMod for path 'spec,(all)' "+
+		"on resource '/ () cluster': Expected last path part to be a map key, allKeys or keyGlob, but was '(all)'")
+}
+
 type modFieldRemoveExample struct {
 	Description string
 	Res         string
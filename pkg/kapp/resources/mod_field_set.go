@@ -0,0 +1,54 @@
+package resources
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type FieldSetMod struct {
+	ResourceMatcher ResourceMatcher
+	Path            Path
+	Value           interface{} // expected to be JSON compatible (e.g. int64 instead of int)
+}
+
+var _ ResourceMod = FieldSetMod{}
+
+func (t FieldSetMod) Apply(res Resource) error {
+	if !t.ResourceMatcher.Matches(res) {
+		return nil
+	}
+	err := t.apply(res.unstructured().Object)
+	if err != nil {
+		return fmt.Errorf("FieldSetMod for path '%s' on resource '%s': %s", t.Path.AsString(), res.Description(), err)
+	}
+	return nil
+}
+
+func (t FieldSetMod) apply(obj interface{}) error {
+	if len(t.Path) == 0 {
+		return fmt.Errorf("Expected path to be non-empty")
+	}
+
+	lastPart := t.Path[len(t.Path)-1]
+	walker := pathWalker{createMissingMaps: true}
+
+	return walker.WalkParents(obj, t.Path, func(obj interface{}, fullPath Path) error {
+		typedObj, ok := obj.(map[string]interface{})
+		if !ok {
+			return pathUnexpectedTypeErr("map", obj, fullPath)
+		}
+
+		keys, err := pathLastPartMapKeys(lastPart, typedObj)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			// Copy value so that resources do not share nested objects
+			typedObj[key] = runtime.DeepCopyJSONValue(t.Value)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,222 @@
+package resources_test
+
+import (
+	"testing"
+
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+)
+
+func TestModFieldSet(t *testing.T) {
+	exs := []modFieldSetExample{
+		{
+			Description: "setting leaf key that exists",
+			Res: `
+spec:
+  replicas: 1`,
+			Expected: `
+spec:
+  replicas: 3`,
+			Path:  ctlres.NewPathFromStrings([]string{"spec", "replicas"}),
+			Value: int64(3),
+		},
+		{
+			Description: "setting leaf key under missing maps",
+			Res: `
+metadata: {}`,
+			Expected: `
+metadata: {}
+spec:
+  strategy:
+    type: Recreate`,
+			Path:  ctlres.NewPathFromStrings([]string{"spec", "strategy", "type"}),
+			Value: "Recreate",
+		},
+		{
+			Description: "setting map value",
+			Res: `
+spec:
+  selector: null`,
+			Expected: `
+spec:
+  selector:
+    app: app`,
+			Path:  ctlres.NewPathFromStrings([]string{"spec", "selector"}),
+			Value: map[string]interface{}{"app": "app"},
+		},
+		{
+			Description: "setting leaf key under array element matched by field",
+			Res: `
+spec:
+  containers:
+  - name: app
+    image: app-image
+  - name: sidecar
+    image: sidecar-image`,
+			Expected: `
+spec:
+  containers:
+  - image: app-image@sha256:abc
+    name: app
+  - image: sidecar-image
+    name: sidecar`,
+			Path: ctlres.Path{
+				ctlres.NewPathPartFromString("spec"),
+				ctlres.NewPathPartFromString("containers"),
+				ctlres.NewPathPartFromMatchField("name", "app"),
+				ctlres.NewPathPartFromString("image"),
+			},
+			Value: "app-image@sha256:abc",
+		},
+		{
+			Description: "skipping array element that does not exist",
+			Res: `
+spec:
+  containers:
+  - name: sidecar
+    image: sidecar-image`,
+			Expected: `
+spec:
+  containers:
+  - image: sidecar-image
+    name: sidecar`,
+			Path: ctlres.Path{
+				ctlres.NewPathPartFromString("spec"),
+				ctlres.NewPathPartFromString("containers"),
+				ctlres.NewPathPartFromMatchField("name", "app"),
+				ctlres.NewPathPartFromString("image"),
+			},
+			Value: "app-image@sha256:abc",
+		},
+		{
+			Description: "setting existing keys matching glob",
+			Res: `
+metadata:
+  annotations:
+    other: val
+    sidecar.istio.io/inject: "true"`,
+			Expected: `
+metadata:
+  annotations:
+    other: val
+    sidecar.istio.io/inject: "false"`,
+			Path: ctlres.Path{
+				ctlres.NewPathPartFromString("metadata"),
+				ctlres.NewPathPartFromString("annotations"),
+				ctlres.NewPathPartFromKeyGlob("sidecar.istio.io/*"),
+			},
+			Value: "false",
+		},
+		{
+			Description: "setting keys at any depth without creating missing maps",
+			Res: `
+metadata:
+  labels:
+    app: app
+spec:
+  template:
+    metadata: {}`,
+			Expected: `
+metadata:
+  labels:
+    app: app
+    tier: web
+spec:
+  template:
+    metadata: {}`,
+			Path: ctlres.Path{
+				ctlres.NewPathPartRecursive(),
+				ctlres.NewPathPartFromString("metadata"),
+				ctlres.NewPathPartFromString("labels"),
+				ctlres.NewPathPartFromString("tier"),
+			},
+			Value: "web",
+		},
+		{
+			Description: "skipping locations of unexpected types below recursive part",
+			Res: `
+spec:
+  containers:
+  - name: app
+    image: app:1
+  - image
+  template:
+    spec:
+      containers:
+        app: {}
+  limits:
+    containers: 3`,
+			Expected: `
+spec:
+  containers:
+  - image: app:2
+    name: app
+  - image
+  limits:
+    containers: 3
+  template:
+    spec:
+      containers:
+        app: {}`,
+			Path: ctlres.Path{
+				ctlres.NewPathPartRecursive(),
+				ctlres.NewPathPartFromString("containers"),
+				ctlres.NewPathPartFromMatchField("name", "app"),
+				ctlres.NewPathPartFromString("image"),
+			},
+			Value: "app:2",
+		},
+	}
+
+	for _, ex := range exs {
+		ex.Check(t)
+	}
+}
+
+func TestModFieldSetErrs(t *testing.T) {
+	res := ctlres.MustNewResourceFromBytes([]byte(`
+spec:
+  replicas: 1`))
+
+	err := ctlres.FieldSetMod{
+		ResourceMatcher: ctlres.AllResourceMatcher{},
+		Path:            ctlres.NewPathFromStrings([]string{"spec", "replicas", "value"}),
+		Value:           "val",
+	}.Apply(res)
+	if err == nil {
+		t.Fatalf("Expected err")
+	}
+
+	expectEquals(t, "non-map error", err.Error(), "FieldSetMod for path 'spec,replicas,value' "+
+		"on resource '/ () cluster': Expected map at path 'spec,replicas', but found float64")
+}
+
+type modFieldSetExample struct {
+	Description string
+	Res         string
+	Path        ctlres.Path
+	Value       interface{}
+	Expected    string
+}
+
+func (e modFieldSetExample) Check(t *testing.T) {
+	res, err := ctlres.NewResourceFromBytes([]byte(e.Res))
+	if err != nil {
+		t.Fatalf("Expected no err, but was %s", err)
+	}
+
+	err = ctlres.FieldSetMod{
+		ResourceMatcher: ctlres.AllResourceMatcher{},
+		Path:            e.Path,
+		Value:           e.Value,
+	}.Apply(res)
+	if err != nil {
+		t.Fatalf("Expected no err, but was %s", err)
+	}
+
+	resultBs, err := res.AsYAMLBytes()
+	if err != nil {
+		t.Fatalf("Expected no err, but was %s", err)
+	}
+
+	expectEqualsStripped(t, e.Description, string(resultBs), e.Expected)
+}
@@ -16,70 +16,23 @@ func (t ObjectRefSetMod) Apply(res Resource) error {
 	if !t.ResourceMatcher.Matches(res) {
 		return nil
 	}
-	err := t.apply(res.unstructured().Object, t.Path)
+	err := t.apply(res.unstructured().Object)
 	if err != nil {
 		return fmt.Errorf("ObjectRefSetMod for path '%s' on resource '%s': %s", t.Path.AsString(), res.Description(), err)
 	}
 	return nil
 }
 
-func (t ObjectRefSetMod) apply(obj interface{}, path Path) error {
-	for i, part := range path {
-		switch {
-		case part.MapKey != nil:
-			typedObj, ok := obj.(map[string]interface{})
-			if !ok {
-				return fmt.Errorf("Unexpected non-map found: %T", obj)
+func (t ObjectRefSetMod) apply(obj interface{}) error {
+	return pathWalker{}.Walk(obj, t.Path, func(obj interface{}, fullPath Path) error {
+		typedObj, ok := obj.(map[string]interface{})
+		if !ok {
+			if obj == nil {
+				return nil // no object reference
 			}
-
-			var found bool
-			obj, found = typedObj[*part.MapKey]
-			if !found {
-				return nil
-			}
-
-		case part.ArrayIndex != nil:
-			switch {
-			case part.ArrayIndex.All != nil:
-				typedObj, ok := obj.([]interface{})
-				if !ok {
-					return fmt.Errorf("Unexpected non-array found: %T", obj)
-				}
-
-				for _, obj := range typedObj {
-					err := t.apply(obj, path[i+1:])
-					if err != nil {
-						return err
-					}
-				}
-
-				return nil // dealt with children, get out
-
-			case part.ArrayIndex.Index != nil:
-				typedObj, ok := obj.([]interface{})
-				if !ok {
-					return fmt.Errorf("Unexpected non-array found: %T", obj)
-				}
-
-				if *part.ArrayIndex.Index < len(typedObj) {
-					return t.apply(typedObj[*part.ArrayIndex.Index], path[i+1:])
-				}
-
-				return nil // index not found, nothing to append to
-
-			default:
-				panic(fmt.Sprintf("Unknown array index: %#v", part.ArrayIndex))
-			}
-
-		default:
-			panic(fmt.Sprintf("Unexpected path part: %#v", part))
+			return pathUnexpectedTypeErr("map", obj, fullPath)
 		}
-	}
-
-	typedObj, ok := obj.(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("Unexpected non-map found: %T", obj)
-	}
 
-	return t.ReplacementFunc(typedObj)
+		return t.ReplacementFunc(typedObj)
+	})
 }
@@ -1,9 +1,12 @@
 package resources
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"strings"
+
+	"github.com/k14s/kapp/pkg/kapp/matcher"
 )
 
 type ResourceMod interface {
@@ -18,7 +21,9 @@ type Path []*PathPart
 
 type PathPart struct {
 	MapKey     *string
+	MapKeys    *PathPartMapKeys
 	ArrayIndex *PathPartArrayIndex
+	Recursive  *bool // matches any number of nested levels (including none)
 }
 
 var _ json.Unmarshaler = &PathPart{}
@@ -26,6 +31,16 @@ var _ json.Unmarshaler = &PathPart{}
 type PathPartArrayIndex struct {
 	Index *int
 	All   *bool `json:"allIndexes"`
+
+	// Matches array elements that have field with given value
+	// (compared against string form of found value)
+	MatchField *string `json:"matchField"`
+	MatchValue *string `json:"value"`
+}
+
+type PathPartMapKeys struct {
+	All  *bool   `json:"allKeys"`
+	Glob *string `json:"keyGlob"` // example: 'sidecar.istio.io/*'
 }
 
 func NewPathFromStrings(strs []string) Path {
@@ -92,14 +107,40 @@ func NewPathPartFromIndexAll() *PathPart {
 	return &PathPart{ArrayIndex: &PathPartArrayIndex{All: &trueBool}}
 }
 
+func NewPathPartFromMatchField(field, value string) *PathPart {
+	return &PathPart{ArrayIndex: &PathPartArrayIndex{MatchField: &field, MatchValue: &value}}
+}
+
+func NewPathPartFromKeysAll() *PathPart {
+	trueBool := true
+	return &PathPart{MapKeys: &PathPartMapKeys{All: &trueBool}}
+}
+
+func NewPathPartFromKeyGlob(glob string) *PathPart {
+	return &PathPart{MapKeys: &PathPartMapKeys{Glob: &glob}}
+}
+
+func NewPathPartRecursive() *PathPart {
+	trueBool := true
+	return &PathPart{Recursive: &trueBool}
+}
+
 func (p *PathPart) AsString() string {
 	switch {
 	case p.MapKey != nil:
 		return *p.MapKey
+	case p.MapKeys != nil && p.MapKeys.All != nil:
+		return "(all keys)"
+	case p.MapKeys != nil && p.MapKeys.Glob != nil:
+		return fmt.Sprintf("(keys %s)", *p.MapKeys.Glob)
 	case p.ArrayIndex != nil && p.ArrayIndex.Index != nil:
 		return fmt.Sprintf("%d", *p.ArrayIndex.Index)
 	case p.ArrayIndex != nil && p.ArrayIndex.All != nil:
 		return "(all)"
+	case p.ArrayIndex != nil && p.ArrayIndex.MatchField != nil:
+		return fmt.Sprintf("(%s=%s)", *p.ArrayIndex.MatchField, *p.ArrayIndex.MatchValue)
+	case p.Recursive != nil:
+		return "(recursive)"
 	default:
 		panic("Unknown path part")
 	}
@@ -107,15 +148,87 @@ func (p *PathPart) AsString() string {
 
 func (p *PathPart) UnmarshalJSON(data []byte) error {
 	var str string
-	var idx PathPartArrayIndex
 
-	switch {
-	case json.Unmarshal(data, &str) == nil:
+	if json.Unmarshal(data, &str) == nil {
 		p.MapKey = &str
-	case json.Unmarshal(data, &idx) == nil:
-		p.ArrayIndex = &idx
+		return nil
+	}
+
+	var obj struct {
+		PathPartArrayIndex
+		PathPartMapKeys
+		Recursive *bool `json:"recursive"`
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	err := dec.Decode(&obj)
+	if err != nil {
+		return fmt.Errorf("Unknown path part '%s': %s", data, err)
+	}
+
+	idx := obj.PathPartArrayIndex
+	keys := obj.PathPartMapKeys
+	var numKinds int
+
+	for _, set := range []bool{idx.Index != nil, idx.All != nil, idx.MatchField != nil,
+		keys.All != nil, keys.Glob != nil, obj.Recursive != nil} {
+
+		if set {
+			numKinds++
+		}
+	}
+
+	switch {
+	case numKinds != 1:
+		return fmt.Errorf("Expected path part '%s' to be either a map key or an object with exactly one of: "+
+			"index, allIndexes, matchField (with value), allKeys, keyGlob, recursive", data)
+
+	case idx.MatchField != nil && idx.MatchValue == nil:
+		return fmt.Errorf("Expected path part '%s' to specify value for matchField", data)
+
+	case (idx.All != nil && !*idx.All) || (keys.All != nil && !*keys.All) || (obj.Recursive != nil && !*obj.Recursive):
+		return fmt.Errorf("Expected path part '%s' to set allIndexes, allKeys or recursive only to true", data)
+
+	case keys.Glob != nil && len(*keys.Glob) == 0:
+		return fmt.Errorf("Expected path part '%s' to specify non-empty keyGlob", data)
+
+	case keys.All != nil || keys.Glob != nil:
+		p.MapKeys = &keys
+
+	case obj.Recursive != nil:
+		p.Recursive = obj.Recursive
+
 	default:
-		return fmt.Errorf("Unknown path part")
+		p.ArrayIndex = &idx
 	}
+
 	return nil
 }
+
+// Matches checks whether map key is selected by this path part
+func (p PathPartMapKeys) Matches(key string) bool {
+	switch {
+	case p.All != nil:
+		return true
+	case p.Glob != nil:
+		return matcher.NewStringMatcher(*p.Glob).Matches(key)
+	default:
+		panic(fmt.Sprintf("Unknown map keys path part: %#v", p))
+	}
+}
+
+// Matches checks whether array element is selected by this path part
+// (only applicable to path parts that match elements by their field)
+func (p PathPartArrayIndex) Matches(obj interface{}) bool {
+	typedObj, ok := obj.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	val, found := typedObj[*p.MatchField]
+	if !found || val == nil {
+		return false
+	}
+	return fmt.Sprintf("%v", val) == *p.MatchValue
+}
@@ -0,0 +1,74 @@
+package resources_test
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+)
+
+func TestPathUnmarshal(t *testing.T) {
+	var path ctlres.Path
+
+	err := yaml.Unmarshal([]byte(`[spec, {index: 0}, {allIndexes: true}, {matchField: name, value: app}, `+
+		`{allKeys: true}, {keyGlob: "sidecar.istio.io/*"}, {recursive: true}, image]`), &path)
+	if err != nil {
+		t.Fatalf("Expected no err, but was %s", err)
+	}
+
+	expectEquals(t, "path", path.AsString(),
+		"spec,0,(all),(name=app),(all keys),(keys sidecar.istio.io/*),(recursive),image")
+}
+
+func TestPathUnmarshalErrs(t *testing.T) {
+	exs := []struct {
+		Path string
+		Err  string
+	}{
+		{
+			Path: `[spec, {unknown: true}]`,
+			Err:  `error unmarshaling JSON: Unknown path part '{"unknown":true}': json: unknown field "unknown"`,
+		},
+		{
+			Path: `[spec, {}]`,
+			Err: `error unmarshaling JSON: Expected path part '{}' to be either a map key or an object ` +
+				`with exactly one of: index, allIndexes, matchField (with value), allKeys, keyGlob, recursive`,
+		},
+		{
+			Path: `[spec, {index: 0, allKeys: true}]`,
+			Err: `error unmarshaling JSON: Expected path part '{"allKeys":true,"index":0}' to be either a map key or an object ` +
+				`with exactly one of: index, allIndexes, matchField (with value), allKeys, keyGlob, recursive`,
+		},
+		{
+			Path: `[spec, {matchField: name}]`,
+			Err:  `error unmarshaling JSON: Expected path part '{"matchField":"name"}' to specify value for matchField`,
+		},
+		{
+			Path: `[spec, {keyGlob: ""}]`,
+			Err:  `error unmarshaling JSON: Expected path part '{"keyGlob":""}' to specify non-empty keyGlob`,
+		},
+		{
+			Path: `[{recursive: false}, data]`,
+			Err:  `error unmarshaling JSON: Expected path part '{"recursive":false}' to set allIndexes, allKeys or recursive only to true`,
+		},
+		{
+			Path: `[data, {allKeys: false}]`,
+			Err:  `error unmarshaling JSON: Expected path part '{"allKeys":false}' to set allIndexes, allKeys or recursive only to true`,
+		},
+		{
+			Path: `[spec, {allIndexes: false}]`,
+			Err:  `error unmarshaling JSON: Expected path part '{"allIndexes":false}' to set allIndexes, allKeys or recursive only to true`,
+		},
+	}
+
+	for _, ex := range exs {
+		var path ctlres.Path
+
+		err := yaml.Unmarshal([]byte(ex.Path), &path)
+		if err == nil {
+			t.Fatalf("Expected err for path %s", ex.Path)
+		}
+
+		expectEquals(t, ex.Path, err.Error(), ex.Err)
+	}
+}
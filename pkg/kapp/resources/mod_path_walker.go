@@ -0,0 +1,286 @@
+package resources
+
+import (
+	"fmt"
+	"sort"
+)
+
+// pathWalker finds all locations within an object that match a path
+// (path parts such as allIndexes may match multiple locations).
+// Missing locations are skipped, hence nothing is visited for them.
+type pathWalker struct {
+	// Creates empty maps for missing (or null) map keys, unless
+	// remaining path contains parts other than map keys
+	// (e.g. arrays cannot be created, so missing locations are skipped)
+	// or path part is below recursive path part
+	createMissingMaps bool
+	// Skips locations of unexpected types instead of failing;
+	// set below recursive path part since it may match locations
+	// unrelated to the rest of the path (e.g. non-array containers field)
+	skipUnexpectedTypes bool
+
+	lastPart *PathPart // set when walking to parents of last path part
+}
+
+// pathWalkFunc receives found object together with its full path
+// in which parts matching multiple locations are replaced with
+// specific map keys and array indexes (except for matchField parts)
+type pathWalkFunc func(obj interface{}, fullPath Path) error
+
+func (w pathWalker) Walk(obj interface{}, path Path, fn pathWalkFunc) error {
+	return w.walk(obj, path, Path{}, fn)
+}
+
+// WalkParents is similar to Walk, but visits objects that may contain
+// last path part (handling of last path part is left to the caller)
+func (w pathWalker) WalkParents(obj interface{}, path Path, fn pathWalkFunc) error {
+	if len(path) == 0 {
+		return fmt.Errorf("Expected path to be non-empty")
+	}
+	w.lastPart = path[len(path)-1]
+	return w.walk(obj, path[:len(path)-1], Path{}, fn)
+}
+
+func (w pathWalker) walk(obj interface{}, path Path, fullPath Path, fn pathWalkFunc) error {
+	if len(path) == 0 {
+		if w.skipUnexpectedTypes && w.lastPart != nil && !w.fits(obj, w.lastPart) {
+			return nil
+		}
+		return fn(obj, fullPath)
+	}
+	if obj == nil {
+		return nil // nothing nested in null value
+	}
+
+	part := path[0]
+
+	switch {
+	case part.MapKey != nil:
+		typedObj, ok := obj.(map[string]interface{})
+		if !ok {
+			return w.unexpectedTypeErr("map", obj, fullPath)
+		}
+
+		val, found := typedObj[*part.MapKey]
+		if !found || val == nil {
+			switch {
+			case w.createMissingMaps && !path.ContainsNonMapKeys():
+				val = map[string]interface{}{}
+				typedObj[*part.MapKey] = val
+			case !found:
+				return nil // nothing to walk
+			}
+		}
+
+		return w.walk(val, path[1:], w.appendPart(fullPath, part), fn)
+
+	case part.MapKeys != nil:
+		typedObj, ok := obj.(map[string]interface{})
+		if !ok {
+			return w.unexpectedTypeErr("map", obj, fullPath)
+		}
+
+		for _, key := range pathSortedMapKeys(typedObj) {
+			if part.MapKeys.Matches(key) {
+				err := w.walk(typedObj[key], path[1:], w.appendPart(fullPath, NewPathPartFromString(key)), fn)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+
+	case part.ArrayIndex != nil:
+		typedObj, ok := obj.([]interface{})
+		if !ok {
+			return w.unexpectedTypeErr("array", obj, fullPath)
+		}
+
+		switch {
+		case part.ArrayIndex.All != nil:
+			for i, item := range typedObj {
+				err := w.walk(item, path[1:], w.appendPart(fullPath, NewPathPartFromIndex(i)), fn)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+
+		case part.ArrayIndex.Index != nil:
+			if *part.ArrayIndex.Index < len(typedObj) {
+				return w.walk(typedObj[*part.ArrayIndex.Index], path[1:], w.appendPart(fullPath, part), fn)
+			}
+			return nil // index not found
+
+		case part.ArrayIndex.MatchField != nil:
+			for _, item := range typedObj {
+				if part.ArrayIndex.Matches(item) {
+					// Keep matchField part so that same element can be found in other
+					// objects (e.g. rebase sources) regardless of its position
+					err := w.walk(item, path[1:], w.appendPart(fullPath, part), fn)
+					if err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+
+		default:
+			panic(fmt.Sprintf("Unknown array index: %#v", part.ArrayIndex))
+		}
+
+	case part.Recursive != nil:
+		nextPart := w.lastPart
+		if len(path) > 1 {
+			nextPart = path[1]
+		}
+		if nextPart == nil {
+			return fmt.Errorf("Expected recursive path part to be followed by other path parts")
+		}
+
+		// Missing maps are never created below recursive part since
+		// created maps would be descended into again (never ending), and
+		// it's ambiguous at which of the levels they should be created
+		w.createMissingMaps = false
+		w.skipUnexpectedTypes = true
+
+		// Match at current level first, then descend (children are
+		// collected after visiting as visiting may modify object).
+		// Levels that cannot contain next path part are skipped.
+		if w.fits(obj, nextPart) {
+			err := w.walk(obj, path[1:], fullPath, fn)
+			if err != nil {
+				return err
+			}
+		}
+
+		switch typedObj := obj.(type) {
+		case map[string]interface{}:
+			for _, key := range pathSortedMapKeys(typedObj) {
+				err := w.walk(typedObj[key], path, w.appendPart(fullPath, NewPathPartFromString(key)), fn)
+				if err != nil {
+					return err
+				}
+			}
+
+		case []interface{}:
+			for i, item := range typedObj {
+				err := w.walk(item, path, w.appendPart(fullPath, NewPathPartFromIndex(i)), fn)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+
+	default:
+		panic(fmt.Sprintf("Unexpected path part: %#v", part))
+	}
+}
+
+func (w pathWalker) unexpectedTypeErr(expectedType string, obj interface{}, fullPath Path) error {
+	if w.skipUnexpectedTypes {
+		return nil
+	}
+	return pathUnexpectedTypeErr(expectedType, obj, fullPath)
+}
+
+func (pathWalker) fits(obj interface{}, part *PathPart) bool {
+	switch {
+	case part.MapKey != nil || part.MapKeys != nil:
+		_, ok := obj.(map[string]interface{})
+		return ok
+	case part.ArrayIndex != nil:
+		_, ok := obj.([]interface{})
+		return ok
+	default:
+		return true
+	}
+}
+
+func (pathWalker) appendPart(fullPath Path, part *PathPart) Path {
+	// Copy to avoid sharing underlying array between siblings
+	return append(append(Path{}, fullPath...), part)
+}
+
+func pathSortedMapKeys(obj map[string]interface{}) []string {
+	var keys []string
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func pathUnexpectedTypeErr(expectedType string, obj interface{}, fullPath Path) error {
+	location := fullPath.AsString()
+	if len(location) == 0 {
+		location = "(root)"
+	}
+	return fmt.Errorf("Expected %s at path '%s', but found %T", expectedType, location, obj)
+}
+
+// pathLastPartMapKeys returns map keys selected by last path part
+// (specific map key or a pattern) from given maps
+func pathLastPartMapKeys(part *PathPart, objs ...map[string]interface{}) ([]string, error) {
+	switch {
+	case part.MapKey != nil:
+		return []string{*part.MapKey}, nil
+
+	case part.MapKeys != nil:
+		seenKeys := map[string]struct{}{}
+		var keys []string
+
+		for _, obj := range objs {
+			for _, key := range pathSortedMapKeys(obj) {
+				if _, found := seenKeys[key]; !found && part.MapKeys.Matches(key) {
+					seenKeys[key] = struct{}{}
+					keys = append(keys, key)
+				}
+			}
+		}
+
+		sort.Strings(keys)
+		return keys, nil
+
+	default:
+		return nil, fmt.Errorf("Expected last path part to be a map key, allKeys or keyGlob, but was '%s'", part.AsString())
+	}
+}
+
+// FindValues returns values found at all locations matched by path
+// (given object is not modified; missing locations are skipped)
+func (p Path) FindValues(obj interface{}) ([]interface{}, error) {
+	var result []interface{}
+
+	err := pathWalker{}.Walk(obj, p, func(obj interface{}, _ Path) error {
+		result = append(result, obj)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// pathObtainValue finds value at full path (it is expected to match at most one location)
+func pathObtainValue(obj interface{}, fullPath Path) (interface{}, bool, error) {
+	var result interface{}
+	var found bool
+
+	err := pathWalker{}.Walk(obj, fullPath, func(obj interface{}, _ Path) error {
+		if !found {
+			result = obj
+			found = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return result, found, nil
+}
@@ -148,6 +148,104 @@ metadata:
 				ctlres.NewPathPartFromIndexAll(),
 			},
 		},
+		{
+			Description: "append to map nested in array element matched by field",
+			Res: `
+spec:
+  containers:
+  - name: app
+  - name: sidecar`,
+			Expected: `
+spec:
+  containers:
+  - env:
+      new-label-key: new-label-val
+    name: app
+  - name: sidecar`,
+			KVs: map[string]string{"new-label-key": "new-label-val"},
+			Path: ctlres.Path{
+				ctlres.NewPathPartFromString("spec"),
+				ctlres.NewPathPartFromString("containers"),
+				ctlres.NewPathPartFromMatchField("name", "app"),
+				ctlres.NewPathPartFromString("env"),
+			},
+		},
+		{
+			Description: "append to existing maps at any depth",
+			Res: `
+metadata:
+  labels: {}
+spec:
+  template:
+    metadata:
+      labels: null
+  volumeClaimTemplates:
+  - metadata:
+      labels:
+        label-key: label-val`,
+			Expected: `
+metadata:
+  labels:
+    new-label-key: new-label-val
+spec:
+  template:
+    metadata:
+      labels: null
+  volumeClaimTemplates:
+  - metadata:
+      labels:
+        label-key: label-val
+        new-label-key: new-label-val`,
+			KVs:            map[string]string{"new-label-key": "new-label-val"},
+			SkipIfNotFound: true,
+			Path: ctlres.Path{
+				ctlres.NewPathPartRecursive(),
+				ctlres.NewPathPartFromString("metadata"),
+				ctlres.NewPathPartFromString("labels"),
+			},
+		},
+		{
+			Description: "does not create missing maps below recursive path part",
+			Res: `
+metadata:
+  name: app
+spec:
+  template:
+    metadata: {}
+    spec:
+      containers:
+      - name: app`,
+			Expected: `
+metadata:
+  name: app
+spec:
+  template:
+    metadata: {}
+    spec:
+      containers:
+      - name: app`,
+			KVs: map[string]string{"new-label-key": "new-label-val"},
+			Path: ctlres.Path{
+				ctlres.NewPathPartRecursive(),
+				ctlres.NewPathPartFromString("metadata"),
+				ctlres.NewPathPartFromString("labels"),
+			},
+		},
+		{
+			Description: "append keys without overriding existing keys",
+			Res: `
+metadata:
+  annotations:
+    ann-key: ann-val`,
+			Expected: `
+metadata:
+  annotations:
+    ann-key: ann-val
+    new-ann-key: new-ann-val`,
+			KVs:            map[string]string{"ann-key": "other-ann-val", "new-ann-key": "new-ann-val"},
+			Path:           ctlres.NewPathFromStrings([]string{"metadata", "annotations"}),
+			SkipIfKeyFound: true,
+		},
 	}
 
 	for _, ex := range exs {
@@ -161,6 +259,9 @@ type modStringMapAppendExample struct {
 	Path        ctlres.Path
 	KVs         map[string]string
 	Expected    string
+
+	SkipIfNotFound bool
+	SkipIfKeyFound bool
 }
 
 func (e modStringMapAppendExample) Check(t *testing.T) {
@@ -173,6 +274,8 @@ func (e modStringMapAppendExample) Check(t *testing.T) {
 		ResourceMatcher: ctlres.AllResourceMatcher{},
 		Path:            e.Path,
 		KVs:             e.KVs,
+		SkipIfNotFound:  e.SkipIfNotFound,
+		SkipIfKeyFound:  e.SkipIfKeyFound,
 	}.Apply(res)
 	if err != nil {
 		t.Fatalf("Expected no err, but was %s", err)
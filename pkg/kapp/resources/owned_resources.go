@@ -0,0 +1,52 @@
+package resources
+
+// OwnedResources selects resources that are owned (directly or indirectly)
+// by given owners via ownerReferences
+type OwnedResources struct {
+	owners   []Resource
+	maxDepth int
+}
+
+func NewOwnedResources(owners []Resource, maxDepth int) OwnedResources {
+	return OwnedResources{owners, maxDepth}
+}
+
+// Select returns owned resources (owners themselves are not included)
+// ordered by their depth in the ownership tree
+func (o OwnedResources) Select(resources []Resource) []Resource {
+	knownUIDs := map[string]struct{}{}
+
+	for _, owner := range o.owners {
+		knownUIDs[owner.UID()] = struct{}{}
+	}
+
+	var result []Resource
+	levelOwners := o.owners
+
+	for depth := 0; depth < o.maxDepth && len(levelOwners) > 0; depth++ {
+		levelOwnerUIDs := map[string]struct{}{}
+
+		for _, owner := range levelOwners {
+			levelOwnerUIDs[owner.UID()] = struct{}{}
+		}
+
+		levelOwners = nil
+
+		for _, res := range resources {
+			if _, found := knownUIDs[res.UID()]; found {
+				continue
+			}
+
+			for _, ref := range res.OwnerRefs() {
+				if _, found := levelOwnerUIDs[string(ref.UID)]; found {
+					knownUIDs[res.UID()] = struct{}{}
+					levelOwners = append(levelOwners, res)
+					result = append(result, res)
+					break
+				}
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,64 @@
+package resources_test
+
+import (
+	"strings"
+	"testing"
+
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+)
+
+func TestOwnedResourcesSelect(t *testing.T) {
+	resourcesBs := `
+apiVersion: example.com/v1
+kind: Database
+metadata:
+  name: db
+  uid: db-uid
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db-sts
+  uid: sts-uid
+  ownerReferences:
+  - {apiVersion: example.com/v1, kind: Database, name: db, uid: db-uid}
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: db-sts-0
+  uid: pod-uid
+  ownerReferences:
+  - {apiVersion: apps/v1, kind: StatefulSet, name: db-sts, uid: sts-uid}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: unrelated
+  uid: cm-uid
+  ownerReferences:
+  - {apiVersion: v1, kind: Secret, name: other, uid: other-uid}
+`
+
+	rs, err := ctlres.NewFileResource(ctlres.NewBytesSource([]byte(resourcesBs))).Resources()
+	if err != nil {
+		t.Fatalf("Expected resources to parse: %s", err)
+	}
+
+	owned := ctlres.NewOwnedResources(rs[:1], 5).Select(rs)
+	expectEquals(t, "all levels", ownedNames(owned), "db-sts,db-sts-0")
+
+	owned = ctlres.NewOwnedResources(rs[:1], 1).Select(rs)
+	expectEquals(t, "bounded depth", ownedNames(owned), "db-sts")
+
+	owned = ctlres.NewOwnedResources(rs[:1], 0).Select(rs)
+	expectEquals(t, "zero depth", ownedNames(owned), "")
+}
+
+func ownedNames(rs []ctlres.Resource) string {
+	var names []string
+	for _, res := range rs {
+		names = append(names, res.Name())
+	}
+	return strings.Join(names, ",")
+}
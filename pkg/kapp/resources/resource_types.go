@@ -199,6 +199,35 @@ func Listable(in []ResourceType) []ResourceType {
 	return out
 }
 
+// MatchingAPIVersionKinds returns types that match any of given matchers
+func MatchingAPIVersionKinds(in []ResourceType, matchers []APIVersionKindMatcher) []ResourceType {
+	var out []ResourceType
+	for _, item := range in {
+		apiVersion := item.GroupVersionResource.GroupVersion().String()
+		for _, matcher := range matchers {
+			if matcher.APIVersion == apiVersion && matcher.Kind == item.APIResource.Kind {
+				out = append(out, item)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// MatchingAPIGroupKinds returns types (of all versions) that match any of given matchers
+func MatchingAPIGroupKinds(in []ResourceType, matchers []APIGroupKindMatcher) []ResourceType {
+	var out []ResourceType
+	for _, item := range in {
+		for _, matcher := range matchers {
+			if matcher.APIGroup == item.GroupVersionResource.Group && matcher.Kind == item.APIResource.Kind {
+				out = append(out, item)
+				break
+			}
+		}
+	}
+	return out
+}
+
 func Matching(in []ResourceType, ref ResourceRef) []ResourceType {
 	partResourceRef := PartialResourceRef{ref.GroupVersionResource}
 	var out []ResourceType
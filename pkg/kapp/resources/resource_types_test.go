@@ -0,0 +1,71 @@
+package resources_test
+
+import (
+	"strings"
+	"testing"
+
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestMatchingAPIVersionKinds(t *testing.T) {
+	resTypes := []ctlres.ResourceType{
+		{
+			GroupVersionResource: schema.GroupVersionResource{Version: "v1", Resource: "pods"},
+			APIResource:          metav1.APIResource{Name: "pods", Kind: "Pod"},
+		},
+		{
+			GroupVersionResource: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "statefulsets"},
+			APIResource:          metav1.APIResource{Name: "statefulsets", Kind: "StatefulSet"},
+		},
+		{
+			GroupVersionResource: schema.GroupVersionResource{Group: "apps", Version: "v1beta1", Resource: "statefulsets"},
+			APIResource:          metav1.APIResource{Name: "statefulsets", Kind: "StatefulSet"},
+		},
+	}
+
+	matched := ctlres.MatchingAPIVersionKinds(resTypes, []ctlres.APIVersionKindMatcher{
+		{APIVersion: "v1", Kind: "Pod"},
+		{APIVersion: "apps/v1", Kind: "StatefulSet"},
+		{APIVersion: "apps/v1", Kind: "Deployment"},
+	})
+
+	var result []string
+	for _, resType := range matched {
+		result = append(result, resType.GroupVersionResource.String())
+	}
+
+	expectEquals(t, "matched types", strings.Join(result, "\n"),
+		"/v1, Resource=pods\napps/v1, Resource=statefulsets")
+}
+
+func TestMatchingAPIGroupKinds(t *testing.T) {
+	resTypes := []ctlres.ResourceType{
+		{
+			GroupVersionResource: schema.GroupVersionResource{Group: "autoscaling", Version: "v1", Resource: "horizontalpodautoscalers"},
+			APIResource:          metav1.APIResource{Name: "horizontalpodautoscalers", Kind: "HorizontalPodAutoscaler"},
+		},
+		{
+			GroupVersionResource: schema.GroupVersionResource{Group: "autoscaling", Version: "v2beta2", Resource: "horizontalpodautoscalers"},
+			APIResource:          metav1.APIResource{Name: "horizontalpodautoscalers", Kind: "HorizontalPodAutoscaler"},
+		},
+		{
+			GroupVersionResource: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"},
+			APIResource:          metav1.APIResource{Name: "deployments", Kind: "Deployment"},
+		},
+	}
+
+	matched := ctlres.MatchingAPIGroupKinds(resTypes, []ctlres.APIGroupKindMatcher{
+		{APIGroup: "autoscaling", Kind: "HorizontalPodAutoscaler"},
+		{APIGroup: "", Kind: "Deployment"},
+	})
+
+	var result []string
+	for _, resType := range matched {
+		result = append(result, resType.GroupVersionResource.String())
+	}
+
+	expectEquals(t, "matched types", strings.Join(result, "\n"),
+		"autoscaling/v1, Resource=horizontalpodautoscalers\nautoscaling/v2beta2, Resource=horizontalpodautoscalers")
+}
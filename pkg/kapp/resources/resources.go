@@ -12,6 +12,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 )
@@ -94,7 +95,11 @@ func (c *Resources) All(resTypes []ResourceType, opts ResourcesAllOpts) ([]Resou
 			client := c.dynamicClient.Resource(resType.GroupVersionResource)
 
 			if resType.Namespaced() {
-				list, err = client.Namespace("").List(*opts.ListOpts)
+				if len(opts.Namespaces) > 0 {
+					list, err = c.allForNamespaces(client, opts.Namespaces, opts.ListOpts)
+				} else {
+					list, err = client.Namespace("").List(*opts.ListOpts)
+				}
 			} else {
 				list, err = client.List(*opts.ListOpts)
 			}
@@ -110,8 +115,14 @@ func (c *Resources) All(resTypes []ResourceType, opts ResourcesAllOpts) ([]Resou
 					return
 				}
 
+				allowedNs, err := c.assumedAllowedNamespaces()
+				if err != nil {
+					fatalErrsCh <- fmt.Errorf("Listing %#v, namespaced: %t: %s", resType.GroupVersionResource, resType.Namespaced(), err)
+					return
+				}
+
 				// TODO improve perf somehow
-				list, err = c.allForNamespaces(client, opts.ListOpts)
+				list, err = c.allForNamespaces(client, allowedNs, opts.ListOpts)
 				if err != nil {
 					fatalErrsCh <- fmt.Errorf("Listing %#v, namespaced: %t: %s", resType.GroupVersionResource, resType.Namespaced(), err)
 					return
@@ -142,13 +153,10 @@ func (c *Resources) All(resTypes []ResourceType, opts ResourcesAllOpts) ([]Resou
 	return resources, nil
 }
 
-func (c *Resources) allForNamespaces(client dynamic.NamespaceableResourceInterface, listOpts *metav1.ListOptions) (*unstructured.UnstructuredList, error) {
-	defer c.logger.DebugFunc("allForNamespaces").Finish()
+func (c *Resources) allForNamespaces(client dynamic.NamespaceableResourceInterface,
+	allowedNs []string, listOpts *metav1.ListOptions) (*unstructured.UnstructuredList, error) {
 
-	allowedNs, err := c.assumedAllowedNamespaces()
-	if err != nil {
-		return nil, err
-	}
+	defer c.logger.DebugFunc("allForNamespaces").Finish()
 
 	var itemsDone sync.WaitGroup
 	fatalErrsCh := make(chan error, len(allowedNs))
@@ -352,6 +360,15 @@ func (c *Resources) Get(resource Resource) (Resource, error) {
 	return NewResourceUnstructured(*item, resType), nil
 }
 
+func (c *Resources) Watch(resType ResourceType, listOpts metav1.ListOptions) (watch.Interface, error) {
+	client := c.dynamicClient.Resource(resType.GroupVersionResource)
+
+	if resType.Namespaced() {
+		return client.Namespace("").Watch(listOpts)
+	}
+	return client.Watch(listOpts)
+}
+
 func (c *Resources) Exists(resource Resource) (bool, error) {
 	if resourcesDebug {
 		t1 := time.Now().UTC()
@@ -465,7 +482,8 @@ func (c *Resources) assumedAllowedNamespaces() ([]string, error) {
 }
 
 type ResourcesAllOpts struct {
-	ListOpts *metav1.ListOptions
+	ListOpts   *metav1.ListOptions
+	Namespaces []string // if set, namespaced types are only listed in these namespaces
 }
 
 type resourceStatusErr struct {
@@ -0,0 +1,102 @@
+package resourcesmisc
+
+import (
+	"fmt"
+
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+type AdmissionRegistrationVxWebhookConfiguration struct {
+	resource  ctlres.Resource
+	relatedRs RelatedResources
+}
+
+func NewAdmissionRegistrationVxWebhookConfiguration(resource ctlres.Resource,
+	relatedRs RelatedResources) *AdmissionRegistrationVxWebhookConfiguration {
+
+	mutMatcher := ctlres.APIGroupKindMatcher{
+		APIGroup: "admissionregistration.k8s.io",
+		Kind:     "MutatingWebhookConfiguration",
+	}
+	valMatcher := ctlres.APIGroupKindMatcher{
+		APIGroup: "admissionregistration.k8s.io",
+		Kind:     "ValidatingWebhookConfiguration",
+	}
+	if mutMatcher.Matches(resource) || valMatcher.Matches(resource) {
+		return &AdmissionRegistrationVxWebhookConfiguration{resource, relatedRs}
+	}
+	return nil
+}
+
+// Cannot use typed webhook configurations since no gurantee which versions are used
+type webhookConfigurationObj struct {
+	Webhooks []struct {
+		Name         string `json:"name"`
+		ClientConfig struct {
+			Service *struct {
+				Namespace string `json:"namespace"`
+				Name      string `json:"name"`
+			} `json:"service"`
+		} `json:"clientConfig"`
+	} `json:"webhooks"`
+}
+
+func (s AdmissionRegistrationVxWebhookConfiguration) IsDoneApplying() DoneApplyState {
+	obj := webhookConfigurationObj{}
+
+	err := s.resource.AsUncheckedTypedObj(&obj)
+	if err != nil {
+		return DoneApplyState{Done: true, Successful: false, Message: fmt.Sprintf("Error: Failed obj conversion: %s", err)}
+	}
+
+	// Without access to endpoints there is nothing to check
+	if s.relatedRs == nil {
+		return DoneApplyState{Done: true, Successful: true}
+	}
+
+	for _, webhook := range obj.Webhooks {
+		svc := webhook.ClientConfig.Service
+		if svc == nil {
+			continue // webhook is called via URL
+		}
+
+		ready, err := s.hasReadyEndpoints(svc.Namespace, svc.Name)
+		if err != nil {
+			return DoneApplyState{Done: true, Successful: false, Message: fmt.Sprintf(
+				"Error: Failed to find endpoints for webhook '%s': %s", webhook.Name, err)}
+		}
+		if !ready {
+			return DoneApplyState{Done: false, Message: fmt.Sprintf(
+				"Waiting for service %s/%s (webhook '%s') to have ready endpoints", svc.Namespace, svc.Name, webhook.Name)}
+		}
+	}
+
+	return DoneApplyState{Done: true, Successful: true}
+}
+
+func (s AdmissionRegistrationVxWebhookConfiguration) hasReadyEndpoints(namespace, name string) (bool, error) {
+	epsRes, err := s.relatedRs.Get("v1", "Endpoints", namespace, name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil // Service may not be created yet
+		}
+		return false, err
+	}
+
+	eps := corev1.Endpoints{}
+
+	err = epsRes.AsTypedObj(&eps)
+	if err != nil {
+		return false, err
+	}
+
+	for _, subset := range eps.Subsets {
+		if len(subset.Addresses) > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
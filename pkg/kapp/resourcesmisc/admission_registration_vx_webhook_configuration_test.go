@@ -0,0 +1,60 @@
+package resourcesmisc_test
+
+import (
+	"testing"
+
+	ctlresm "github.com/k14s/kapp/pkg/kapp/resourcesmisc"
+)
+
+func TestAdmissionRegistrationVxWebhookConfiguration(t *testing.T) {
+	configYAML := `
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: webhook
+webhooks:
+- name: check.example.com
+  clientConfig:
+    service:
+      namespace: webhooks
+      name: check
+`
+
+	relatedRs := fakeRelatedResources{
+		"v1/Endpoints/webhooks/check": `
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: check
+  namespace: webhooks
+subsets:
+- notReadyAddresses:
+  - ip: 10.0.0.1
+`,
+	}
+
+	state := ctlresm.NewAdmissionRegistrationVxWebhookConfiguration(mustNewResource(configYAML), relatedRs).IsDoneApplying()
+	expectedState := ctlresm.DoneApplyState{
+		Done:    false,
+		Message: "Waiting for service webhooks/check (webhook 'check.example.com') to have ready endpoints",
+	}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	relatedRs["v1/Endpoints/webhooks/check"] = `
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: check
+  namespace: webhooks
+subsets:
+- addresses:
+  - ip: 10.0.0.1
+`
+
+	state = ctlresm.NewAdmissionRegistrationVxWebhookConfiguration(mustNewResource(configYAML), relatedRs).IsDoneApplying()
+	if state != (ctlresm.DoneApplyState{Done: true, Successful: true}) {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+}
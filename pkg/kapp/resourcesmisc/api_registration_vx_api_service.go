@@ -0,0 +1,49 @@
+package resourcesmisc
+
+import (
+	"fmt"
+
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+)
+
+type ApiRegistrationVxAPIService struct {
+	resource ctlres.Resource
+}
+
+func NewApiRegistrationVxAPIService(resource ctlres.Resource) *ApiRegistrationVxAPIService {
+	matcher := ctlres.APIGroupKindMatcher{
+		APIGroup: "apiregistration.k8s.io",
+		Kind:     "APIService",
+	}
+	if matcher.Matches(resource) {
+		return &ApiRegistrationVxAPIService{resource}
+	}
+	return nil
+}
+
+func (s ApiRegistrationVxAPIService) IsDoneApplying() DoneApplyState {
+	for _, cond := range (Conditions{s.resource}).All() {
+		if cond.Type == "Available" {
+			if cond.Status == "True" {
+				return DoneApplyState{Done: true, Successful: true}
+			}
+			// Backing service may not be ready yet, hence keep waiting
+			return DoneApplyState{Done: false, Message: fmt.Sprintf(
+				"Condition Available is not True (%s): %s (message: %s)", cond.Status, cond.Reason, cond.Message)}
+		}
+	}
+
+	return DoneApplyState{Done: false, Message: "Condition Available is not set"}
+}
+
+/*
+
+status:
+  conditions:
+  - lastTransitionTime: "2019-11-05T21:51:41Z"
+    message: 'endpoints for service/metrics-server in "kube-system" have no addresses'
+    reason: MissingEndpoints
+    status: "False"
+    type: Available
+
+*/
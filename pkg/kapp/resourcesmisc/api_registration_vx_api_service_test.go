@@ -0,0 +1,47 @@
+package resourcesmisc_test
+
+import (
+	"strings"
+	"testing"
+
+	ctlresm "github.com/k14s/kapp/pkg/kapp/resourcesmisc"
+)
+
+func TestApiRegistrationVxAPIServiceAvailable(t *testing.T) {
+	apiSvcYAML := `
+apiVersion: apiregistration.k8s.io/v1
+kind: APIService
+metadata:
+  name: v1beta1.metrics.k8s.io
+status: {}
+`
+
+	state := ctlresm.NewApiRegistrationVxAPIService(mustNewResource(apiSvcYAML)).IsDoneApplying()
+	expectedState := ctlresm.DoneApplyState{Done: false, Message: "Condition Available is not set"}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	apiSvcYAML = strings.Replace(apiSvcYAML, "status: {}", `status:
+  conditions:
+  - type: Available
+    status: "False"
+    reason: MissingEndpoints
+    message: endpoints for service/metrics-server have no addresses`, -1)
+
+	state = ctlresm.NewApiRegistrationVxAPIService(mustNewResource(apiSvcYAML)).IsDoneApplying()
+	expectedState = ctlresm.DoneApplyState{
+		Done:    false,
+		Message: "Condition Available is not True (False): MissingEndpoints (message: endpoints for service/metrics-server have no addresses)",
+	}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	apiSvcYAML = strings.Replace(apiSvcYAML, `status: "False"`, `status: "True"`, -1)
+
+	state = ctlresm.NewApiRegistrationVxAPIService(mustNewResource(apiSvcYAML)).IsDoneApplying()
+	if state != (ctlresm.DoneApplyState{Done: true, Successful: true}) {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+}
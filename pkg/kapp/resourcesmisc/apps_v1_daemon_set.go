@@ -5,19 +5,29 @@ import (
 
 	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// Set by DaemonSet controller on DaemonSet (tracks changes to pod template only)
+	appsV1DaemonSetTemplateGenerationAnnKey = "deprecated.daemonset.template.generation"
+	// Set by DaemonSet controller on Pods it creates
+	appsV1DaemonSetTemplateGenerationLabelKey = "pod-template-generation"
 )
 
 type AppsV1DaemonSet struct {
-	resource ctlres.Resource
+	resource     ctlres.Resource
+	associatedRs []ctlres.Resource
+	failureOpts  PodFailureOpts
 }
 
-func NewAppsV1DaemonSet(resource ctlres.Resource) *AppsV1DaemonSet {
+func NewAppsV1DaemonSet(resource ctlres.Resource, associatedRs []ctlres.Resource, failureOpts PodFailureOpts) *AppsV1DaemonSet {
 	matcher := ctlres.APIVersionKindMatcher{
 		APIVersion: "apps/v1",
 		Kind:       "DaemonSet",
 	}
 	if matcher.Matches(resource) {
-		return &AppsV1DaemonSet{resource}
+		return &AppsV1DaemonSet{resource, associatedRs, failureOpts}
 	}
 	return nil
 }
@@ -35,6 +45,17 @@ func (s AppsV1DaemonSet) IsDoneApplying() DoneApplyState {
 			"Waiting for generation %d to be observed", dset.Generation)}
 	}
 
+	// Fail early if Pods of the latest template generation are not going to recover
+	// (DaemonSet generation also changes when non-template fields are updated)
+	if templateGen, found := dset.Annotations[appsV1DaemonSetTemplateGenerationAnnKey]; found {
+		failedState := failedPodState(s.associatedRs, s.failureOpts, func(pod corev1.Pod) bool {
+			return pod.Labels[appsV1DaemonSetTemplateGenerationLabelKey] == templateGen
+		})
+		if failedState != nil {
+			return *failedState
+		}
+	}
+
 	if dset.Status.NumberUnavailable > 0 {
 		return DoneApplyState{Done: false, Message: fmt.Sprintf(
 			"Waiting for %d unavailable pods", dset.Status.NumberUnavailable)}
@@ -0,0 +1,71 @@
+package resourcesmisc_test
+
+import (
+	"strings"
+	"testing"
+
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	ctlresm "github.com/k14s/kapp/pkg/kapp/resourcesmisc"
+)
+
+func TestAppsV1DaemonSetFailedPods(t *testing.T) {
+	configYAML := `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: app
+  annotations:
+    deprecated.daemonset.template.generation: "2"
+  generation: 3
+status:
+  observedGeneration: 3
+  numberUnavailable: 1
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: app-old
+  labels:
+    pod-template-generation: "1"
+status:
+  phase: Running
+  containerStatuses:
+  - name: app
+    restartCount: 10
+    state:
+      waiting:
+        reason: CrashLoopBackOff
+`
+
+	state := buildDaemonSet(configYAML, t).IsDoneApplying()
+	expectedState := ctlresm.DoneApplyState{
+		Done:       false,
+		Successful: false,
+		Message:    "Waiting for 1 unavailable pods",
+	}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	// DaemonSet generation (3) differs from template generation (2)
+	configYAML = strings.Replace(configYAML, `pod-template-generation: "1"`, `pod-template-generation: "2"`, -1)
+
+	state = buildDaemonSet(configYAML, t).IsDoneApplying()
+	expectedState = ctlresm.DoneApplyState{
+		Done:       true,
+		Successful: false,
+		Message:    "Pod 'app-old' failed: Container 'app' is crash looping (restarted 10 times): CrashLoopBackOff",
+	}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+}
+
+func buildDaemonSet(resourcesBs string, t *testing.T) *ctlresm.AppsV1DaemonSet {
+	newResources, err := ctlres.NewFileResource(ctlres.NewBytesSource([]byte(resourcesBs))).Resources()
+	if err != nil {
+		t.Fatalf("Expected resources to parse")
+	}
+
+	return ctlresm.NewAppsV1DaemonSet(newResources[0], newResources[1:], ctlresm.DefaultPodFailureOpts())
+}
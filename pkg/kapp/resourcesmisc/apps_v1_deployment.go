@@ -17,15 +17,16 @@ const (
 type AppsV1Deployment struct {
 	resource     ctlres.Resource
 	associatedRs []ctlres.Resource
+	failureOpts  PodFailureOpts
 }
 
-func NewAppsV1Deployment(resource ctlres.Resource, associatedRs []ctlres.Resource) *AppsV1Deployment {
+func NewAppsV1Deployment(resource ctlres.Resource, associatedRs []ctlres.Resource, failureOpts PodFailureOpts) *AppsV1Deployment {
 	matcher := ctlres.APIVersionKindMatcher{
 		APIVersion: "apps/v1",
 		Kind:       "Deployment",
 	}
 	if matcher.Matches(resource) {
-		return &AppsV1Deployment{resource, associatedRs}
+		return &AppsV1Deployment{resource, associatedRs, failureOpts}
 	}
 	return nil
 }
@@ -59,6 +60,20 @@ func (s AppsV1Deployment) IsDoneApplying() DoneApplyState {
 		}
 	}
 
+	// Fail early if Pods of the latest revision are not going to recover
+	// (Pods of previous revisions are ignored since they are being replaced)
+	if rs, err := s.findLatestReplicaSet(dep); err == nil {
+		podTemplateHash, found := rs.resource.Labels()[appsv1.DefaultDeploymentUniqueLabelKey]
+		if found {
+			failedState := failedPodState(s.associatedRs, s.failureOpts, func(pod corev1.Pod) bool {
+				return pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey] == podTemplateHash
+			})
+			if failedState != nil {
+				return *failedState
+			}
+		}
+	}
+
 	// TODO ideally we would not condition this on len of associated resources
 	if len(s.associatedRs) > 0 {
 		minRepAvailable, found := s.resource.Annotations()[appsV1DeploymentWaitMinimumReplicasAvailableAnnKey]
@@ -54,11 +54,117 @@ status:
 	}
 }
 
+func TestAppsV1DeploymentFailedPods(t *testing.T) {
+	configYAML := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  annotations:
+    deployment.kubernetes.io/revision: "2"
+  generation: 1
+status:
+  observedGeneration: 1
+  unavailableReplicas: 1
+---
+apiVersion: apps/v1
+kind: ReplicaSet
+metadata:
+  name: app-new
+  annotations:
+    deployment.kubernetes.io/revision: "2"
+  labels:
+    pod-template-hash: new
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: app-old
+  labels:
+    pod-template-hash: old
+status:
+  phase: Running
+  containerStatuses:
+  - name: app
+    restartCount: 10
+    state:
+      waiting:
+        reason: CrashLoopBackOff
+`
+
+	state := buildDep(configYAML, t).IsDoneApplying()
+	expectedState := ctlresm.DoneApplyState{
+		Done:       false,
+		Successful: false,
+		Message:    "Waiting for 1 unavailable replicas",
+	}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	configYAML = strings.Replace(configYAML, "pod-template-hash: old", "pod-template-hash: new", -1)
+
+	state = buildDep(configYAML, t).IsDoneApplying()
+	expectedState = ctlresm.DoneApplyState{
+		Done:       true,
+		Successful: false,
+		Message:    "Pod 'app-old' failed: Container 'app' is crash looping (restarted 10 times): CrashLoopBackOff",
+	}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+}
+
+func TestAppsV1DeploymentEvictedPods(t *testing.T) {
+	configYAML := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  annotations:
+    deployment.kubernetes.io/revision: "1"
+  generation: 1
+status:
+  observedGeneration: 1
+  unavailableReplicas: 1
+---
+apiVersion: apps/v1
+kind: ReplicaSet
+metadata:
+  name: app-new
+  annotations:
+    deployment.kubernetes.io/revision: "1"
+  labels:
+    pod-template-hash: new
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: app-evicted
+  labels:
+    pod-template-hash: new
+status:
+  phase: Failed
+  reason: Evicted
+  message: 'The node was low on resource: memory.'
+`
+
+	state := buildDep(configYAML, t).IsDoneApplying()
+	expectedState := ctlresm.DoneApplyState{
+		Done:       false,
+		Successful: false,
+		Message:    "Waiting for 1 unavailable replicas",
+	}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+}
+
 func buildDep(resourcesBs string, t *testing.T) *ctlresm.AppsV1Deployment {
 	newResources, err := ctlres.NewFileResource(ctlres.NewBytesSource([]byte(resourcesBs))).Resources()
 	if err != nil {
 		t.Fatalf("Expected resources to parse")
 	}
 
-	return ctlresm.NewAppsV1Deployment(newResources[0], newResources[1:])
+	return ctlresm.NewAppsV1Deployment(newResources[0], newResources[1:], ctlresm.DefaultPodFailureOpts())
 }
@@ -0,0 +1,159 @@
+package resourcesmisc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	appsV1StatefulSetWaitMinimumReplicasAvailableAnnKey = "kapp.k14s.io/apps-v1-stateful-set-wait-minimum-replicas-available" // values: "10", "5%"
+)
+
+type AppsV1StatefulSet struct {
+	resource     ctlres.Resource
+	associatedRs []ctlres.Resource
+	failureOpts  PodFailureOpts
+}
+
+func NewAppsV1StatefulSet(resource ctlres.Resource, associatedRs []ctlres.Resource, failureOpts PodFailureOpts) *AppsV1StatefulSet {
+	matcher := ctlres.APIVersionKindMatcher{
+		APIVersion: "apps/v1",
+		Kind:       "StatefulSet",
+	}
+	if matcher.Matches(resource) {
+		return &AppsV1StatefulSet{resource, associatedRs, failureOpts}
+	}
+	return nil
+}
+
+func (s AppsV1StatefulSet) IsDoneApplying() DoneApplyState {
+	sts := appsv1.StatefulSet{}
+
+	err := s.resource.AsTypedObj(&sts)
+	if err != nil {
+		return DoneApplyState{Done: true, Successful: false, Message: fmt.Sprintf("Error: Failed obj conversion: %s", err)}
+	}
+
+	if sts.Generation != sts.Status.ObservedGeneration {
+		return DoneApplyState{Done: false, Message: fmt.Sprintf(
+			"Waiting for generation %d to be observed", sts.Generation)}
+	}
+
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+
+	// Fail early if Pods of the update revision are not going to recover
+	failedState := failedPodState(s.associatedRs, s.failureOpts, func(pod corev1.Pod) bool {
+		return pod.Labels[appsv1.StatefulSetRevisionLabel] == sts.Status.UpdateRevision
+	})
+	if failedState != nil {
+		return *failedState
+	}
+
+	// TODO ideally we would not condition this on len of associated resources
+	if len(s.associatedRs) > 0 {
+		minRepAvailable, found := s.resource.Annotations()[appsV1StatefulSetWaitMinimumReplicasAvailableAnnKey]
+		if found {
+			return s.isMinReplicasAvailable(sts, int(replicas), minRepAvailable)
+		}
+	}
+
+	// Pods are not updated automatically with OnDelete strategy,
+	// hence only wait for existing Pods to become ready
+	if sts.Spec.UpdateStrategy.Type != appsv1.OnDeleteStatefulSetStrategyType {
+		var partition int32
+		if sts.Spec.UpdateStrategy.RollingUpdate != nil && sts.Spec.UpdateStrategy.RollingUpdate.Partition != nil {
+			partition = *sts.Spec.UpdateStrategy.RollingUpdate.Partition
+		}
+
+		// Only Pods with ordinal >= partition are updated
+		expectedUpdatedReplicas := replicas - partition
+		if expectedUpdatedReplicas < 0 {
+			expectedUpdatedReplicas = 0
+		}
+
+		if sts.Status.UpdatedReplicas < expectedUpdatedReplicas {
+			msg := fmt.Sprintf("Waiting for %d replicas to be updated", expectedUpdatedReplicas-sts.Status.UpdatedReplicas)
+			if partition > 0 {
+				msg += fmt.Sprintf(" (partition %d)", partition)
+			}
+			return DoneApplyState{Done: false, Message: msg}
+		}
+
+		// Current revision is only advanced once all Pods are updated
+		if partition == 0 && sts.Status.CurrentRevision != sts.Status.UpdateRevision {
+			return DoneApplyState{Done: false, Message: fmt.Sprintf(
+				"Waiting for current revision %s to become update revision %s",
+				sts.Status.CurrentRevision, sts.Status.UpdateRevision)}
+		}
+	}
+
+	if sts.Status.ReadyReplicas < replicas {
+		return DoneApplyState{Done: false, Message: fmt.Sprintf(
+			"Waiting for %d unready replicas", replicas-sts.Status.ReadyReplicas)}
+	}
+
+	return DoneApplyState{Done: true, Successful: true}
+}
+
+func (s AppsV1StatefulSet) isMinReplicasAvailable(sts appsv1.StatefulSet, totalReplicas int, expectedMinRepAvailableStr string) DoneApplyState {
+	isPercent := strings.HasSuffix(expectedMinRepAvailableStr, "%")
+
+	minRepAvailable, err := strconv.Atoi(strings.TrimSuffix(expectedMinRepAvailableStr, "%"))
+	if err != nil {
+		return DoneApplyState{Done: true, Successful: false,
+			Message: fmt.Sprintf("Error: Failed to parse %s: %s", appsV1StatefulSetWaitMinimumReplicasAvailableAnnKey, err)}
+	}
+
+	if isPercent {
+		minRepAvailable = totalReplicas * minRepAvailable / 100
+	}
+
+	if minRepAvailable > totalReplicas {
+		minRepAvailable = totalReplicas
+	}
+	if totalReplicas > 0 && minRepAvailable <= 0 {
+		minRepAvailable = 1
+	}
+
+	// Count ready Pods that run update revision since status
+	// does not indicate how many of updated replicas are ready
+	var updatedReadyReplicas int
+
+	for _, res := range s.associatedRs {
+		pod := corev1.Pod{}
+
+		if !(ctlres.APIVersionKindMatcher{APIVersion: "v1", Kind: "Pod"}).Matches(res) {
+			continue
+		}
+
+		err := res.AsTypedObj(&pod)
+		if err != nil {
+			return DoneApplyState{Done: true, Successful: false, Message: fmt.Sprintf("Error: Failed obj conversion: %s", err)}
+		}
+
+		if pod.Labels[appsv1.StatefulSetRevisionLabel] != sts.Status.UpdateRevision {
+			continue
+		}
+
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == corev1.PodReady && cond.Status == corev1.ConditionTrue {
+				updatedReadyReplicas++
+			}
+		}
+	}
+
+	if updatedReadyReplicas < minRepAvailable {
+		return DoneApplyState{Done: false, Message: fmt.Sprintf(
+			"Waiting for at least %d updated ready replicas (currently %d updated ready)", minRepAvailable, updatedReadyReplicas)}
+	}
+
+	return DoneApplyState{Done: true, Successful: true}
+}
@@ -0,0 +1,178 @@
+package resourcesmisc_test
+
+import (
+	"strings"
+	"testing"
+
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	ctlresm "github.com/k14s/kapp/pkg/kapp/resourcesmisc"
+)
+
+func TestAppsV1StatefulSetRollingUpdate(t *testing.T) {
+	configYAML := `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: app
+  generation: 2
+spec:
+  replicas: 3
+  updateStrategy:
+    type: RollingUpdate
+    rollingUpdate:
+      partition: 0
+status:
+  observedGeneration: 2
+  replicas: 3
+  readyReplicas: 3
+  updatedReplicas: 1
+  currentRevision: app-1
+  updateRevision: app-2
+`
+
+	state := buildStatefulSet(configYAML, t).IsDoneApplying()
+	expectedState := ctlresm.DoneApplyState{
+		Done:       false,
+		Successful: false,
+		Message:    "Waiting for 2 replicas to be updated",
+	}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	configYAML = strings.Replace(configYAML, "updatedReplicas: 1", "updatedReplicas: 3", -1)
+
+	state = buildStatefulSet(configYAML, t).IsDoneApplying()
+	expectedState = ctlresm.DoneApplyState{
+		Done:       false,
+		Successful: false,
+		Message:    "Waiting for current revision app-1 to become update revision app-2",
+	}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	configYAML = strings.Replace(configYAML, "currentRevision: app-1", "currentRevision: app-2", -1)
+
+	state = buildStatefulSet(configYAML, t).IsDoneApplying()
+	if state != (ctlresm.DoneApplyState{Done: true, Successful: true, Message: ""}) {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+}
+
+func TestAppsV1StatefulSetPartition(t *testing.T) {
+	configYAML := `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: app
+  generation: 2
+spec:
+  replicas: 3
+  updateStrategy:
+    type: RollingUpdate
+    rollingUpdate:
+      partition: 2
+status:
+  observedGeneration: 2
+  replicas: 3
+  readyReplicas: 2
+  updatedReplicas: 0
+  currentRevision: app-1
+  updateRevision: app-2
+`
+
+	state := buildStatefulSet(configYAML, t).IsDoneApplying()
+	expectedState := ctlresm.DoneApplyState{
+		Done:       false,
+		Successful: false,
+		Message:    "Waiting for 1 replicas to be updated (partition 2)",
+	}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	configYAML = strings.Replace(configYAML, "updatedReplicas: 0", "updatedReplicas: 1", -1)
+
+	state = buildStatefulSet(configYAML, t).IsDoneApplying()
+	expectedState = ctlresm.DoneApplyState{
+		Done:       false,
+		Successful: false,
+		Message:    "Waiting for 1 unready replicas",
+	}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	configYAML = strings.Replace(configYAML, "readyReplicas: 2", "readyReplicas: 3", -1)
+
+	state = buildStatefulSet(configYAML, t).IsDoneApplying()
+	if state != (ctlresm.DoneApplyState{Done: true, Successful: true, Message: ""}) {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+}
+
+func TestAppsV1StatefulSetMinRepAvailable(t *testing.T) {
+	configYAML := `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: app
+  annotations:
+    kapp.k14s.io/apps-v1-stateful-set-wait-minimum-replicas-available: "2"
+  generation: 2
+spec:
+  replicas: 3
+status:
+  observedGeneration: 2
+  updateRevision: app-2
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: app-2
+  labels:
+    controller-revision-hash: app-2
+status:
+  conditions:
+  - type: Ready
+    status: "True"
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: app-1
+  labels:
+    controller-revision-hash: app-1
+status:
+  conditions:
+  - type: Ready
+    status: "True"
+`
+
+	state := buildStatefulSet(configYAML, t).IsDoneApplying()
+	expectedState := ctlresm.DoneApplyState{
+		Done:       false,
+		Successful: false,
+		Message:    "Waiting for at least 2 updated ready replicas (currently 1 updated ready)",
+	}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	configYAML = strings.Replace(configYAML, "controller-revision-hash: app-1", "controller-revision-hash: app-2", -1)
+
+	state = buildStatefulSet(configYAML, t).IsDoneApplying()
+	if state != (ctlresm.DoneApplyState{Done: true, Successful: true, Message: ""}) {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+}
+
+func buildStatefulSet(resourcesBs string, t *testing.T) *ctlresm.AppsV1StatefulSet {
+	newResources, err := ctlres.NewFileResource(ctlres.NewBytesSource([]byte(resourcesBs))).Resources()
+	if err != nil {
+		t.Fatalf("Expected resources to parse")
+	}
+
+	return ctlresm.NewAppsV1StatefulSet(newResources[0], newResources[1:], ctlresm.DefaultPodFailureOpts())
+}
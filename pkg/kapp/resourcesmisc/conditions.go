@@ -41,6 +41,39 @@ func (c Conditions) IsAllTrue() (bool, string) {
 	return true, ""
 }
 
+type Condition struct {
+	Type               string
+	Status             string
+	Reason             string
+	Message            string
+	ObservedGeneration int64
+}
+
+func (c Conditions) All() []Condition {
+	var result []Condition
+	if conditions, ok := c.resource.Status()["conditions"].([]interface{}); ok {
+		for _, cond := range conditions {
+			if typedCond, ok := cond.(map[string]interface{}); ok {
+				resultCond := Condition{}
+				resultCond.Type, _ = typedCond["type"].(string)
+				resultCond.Status, _ = typedCond["status"].(string)
+				resultCond.Reason, _ = typedCond["reason"].(string)
+				resultCond.Message, _ = typedCond["message"].(string)
+
+				switch typedGen := typedCond["observedGeneration"].(type) {
+				case int64:
+					resultCond.ObservedGeneration = typedGen
+				case float64:
+					resultCond.ObservedGeneration = int64(typedGen)
+				}
+
+				result = append(result, resultCond)
+			}
+		}
+	}
+	return result
+}
+
 func (c Conditions) statuses() map[string]string {
 	statuses := map[string]string{}
 	if conditions, ok := c.resource.Status()["conditions"].([]interface{}); ok {
@@ -0,0 +1,89 @@
+package resourcesmisc
+
+import (
+	"fmt"
+
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+)
+
+const (
+	coreV1PVCBetaStorageClassAnnKey = "volume.beta.kubernetes.io/storage-class"
+)
+
+type CoreV1PersistentVolumeClaim struct {
+	resource  ctlres.Resource
+	relatedRs RelatedResources
+}
+
+func NewCoreV1PersistentVolumeClaim(resource ctlres.Resource, relatedRs RelatedResources) *CoreV1PersistentVolumeClaim {
+	matcher := ctlres.APIVersionKindMatcher{
+		APIVersion: "v1",
+		Kind:       "PersistentVolumeClaim",
+	}
+	if matcher.Matches(resource) {
+		return &CoreV1PersistentVolumeClaim{resource, relatedRs}
+	}
+	return nil
+}
+
+func (s CoreV1PersistentVolumeClaim) IsDoneApplying() DoneApplyState {
+	pvc := corev1.PersistentVolumeClaim{}
+
+	err := s.resource.AsTypedObj(&pvc)
+	if err != nil {
+		return DoneApplyState{Done: true, Successful: false, Message: fmt.Sprintf("Error: Failed obj conversion: %s", err)}
+	}
+
+	switch pvc.Status.Phase {
+	case corev1.ClaimBound:
+		return DoneApplyState{Done: true, Successful: true}
+
+	case corev1.ClaimLost:
+		return DoneApplyState{Done: true, Successful: false, Message: "Phase is lost"}
+
+	default:
+		// Claims with storage class that delays binding
+		// will not be bound until Pod that uses them is scheduled
+		waitForConsumer, err := s.isWaitingForFirstConsumer(pvc)
+		if err != nil {
+			// Storage class may not be created yet (or may not be readable)
+			return DoneApplyState{Done: false, Message: fmt.Sprintf(
+				"Waiting to be bound (failed to find storage class: %s)", err)}
+		}
+		if waitForConsumer {
+			return DoneApplyState{Done: true, Successful: true, Message: "Waiting for first consumer to be created"}
+		}
+
+		return DoneApplyState{Done: false, Message: "Waiting to be bound"}
+	}
+}
+
+func (s CoreV1PersistentVolumeClaim) isWaitingForFirstConsumer(pvc corev1.PersistentVolumeClaim) (bool, error) {
+	if s.relatedRs == nil {
+		return false, nil
+	}
+
+	scName := pvc.Annotations[coreV1PVCBetaStorageClassAnnKey]
+	if pvc.Spec.StorageClassName != nil {
+		scName = *pvc.Spec.StorageClassName
+	}
+	if len(scName) == 0 {
+		return false, nil
+	}
+
+	scRes, err := s.relatedRs.Get("storage.k8s.io/v1", "StorageClass", "", scName)
+	if err != nil {
+		return false, err
+	}
+
+	sc := storagev1.StorageClass{}
+
+	err = scRes.AsTypedObj(&sc)
+	if err != nil {
+		return false, err
+	}
+
+	return sc.VolumeBindingMode != nil && *sc.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer, nil
+}
@@ -0,0 +1,92 @@
+package resourcesmisc_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	ctlresm "github.com/k14s/kapp/pkg/kapp/resourcesmisc"
+)
+
+func TestCoreV1PersistentVolumeClaimWaitForFirstConsumer(t *testing.T) {
+	pvcYAML := `
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: data
+  namespace: default
+spec:
+  storageClassName: local
+status:
+  phase: Pending
+`
+
+	relatedRs := fakeRelatedResources{
+		"storage.k8s.io/v1/StorageClass//local": `
+apiVersion: storage.k8s.io/v1
+kind: StorageClass
+metadata:
+  name: local
+volumeBindingMode: Immediate
+`,
+	}
+
+	state := ctlresm.NewCoreV1PersistentVolumeClaim(mustNewResource(pvcYAML), relatedRs).IsDoneApplying()
+	expectedState := ctlresm.DoneApplyState{Done: false, Message: "Waiting to be bound"}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	relatedRs["storage.k8s.io/v1/StorageClass//local"] = strings.Replace(
+		relatedRs["storage.k8s.io/v1/StorageClass//local"], "Immediate", "WaitForFirstConsumer", -1)
+
+	state = ctlresm.NewCoreV1PersistentVolumeClaim(mustNewResource(pvcYAML), relatedRs).IsDoneApplying()
+	expectedState = ctlresm.DoneApplyState{Done: true, Successful: true, Message: "Waiting for first consumer to be created"}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	pvcYAML = strings.Replace(pvcYAML, "phase: Pending", "phase: Bound", -1)
+
+	state = ctlresm.NewCoreV1PersistentVolumeClaim(mustNewResource(pvcYAML), relatedRs).IsDoneApplying()
+	if state != (ctlresm.DoneApplyState{Done: true, Successful: true}) {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+}
+
+func TestCoreV1PersistentVolumeClaimMissingStorageClass(t *testing.T) {
+	pvcYAML := `
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: data
+  namespace: default
+spec:
+  storageClassName: local
+status:
+  phase: Pending
+`
+
+	state := ctlresm.NewCoreV1PersistentVolumeClaim(mustNewResource(pvcYAML), fakeRelatedResources{}).IsDoneApplying()
+	expectedState := ctlresm.DoneApplyState{Done: false, Message: "Waiting to be bound (failed to find storage class: Not found)"}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+}
+
+type fakeRelatedResources map[string]string
+
+var _ ctlresm.RelatedResources = fakeRelatedResources{}
+
+func (r fakeRelatedResources) Get(apiVersion, kind, namespace, name string) (ctlres.Resource, error) {
+	resYAML, found := r[apiVersion+"/"+kind+"/"+namespace+"/"+name]
+	if !found {
+		return nil, fmt.Errorf("Not found")
+	}
+	return ctlres.NewResourceFromBytes([]byte(resYAML))
+}
+
+func mustNewResource(resYAML string) ctlres.Resource {
+	return ctlres.MustNewResourceFromBytes([]byte(resYAML))
+}
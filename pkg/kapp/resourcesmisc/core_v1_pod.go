@@ -2,23 +2,43 @@ package resourcesmisc
 
 import (
 	"fmt"
+	"strconv"
+	"time"
 
 	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	coreV1PodWaitFailureRestartThresholdAnnKey  = "kapp.k14s.io/core-v1-pod-wait-failure-restart-threshold"   // values: "5"; "0" disables
+	coreV1PodWaitFailureImagePullDurationAnnKey = "kapp.k14s.io/core-v1-pod-wait-failure-image-pull-duration" // values: "5m"; "0" disables
+)
+
+// PodFailureOpts configures detection of containers that are not likely
+// to recover (can be overridden per Pod via annotations)
+type PodFailureOpts struct {
+	RestartThreshold  int           // minimum number of restarts of crash looping container; 0 disables
+	ImagePullDuration time.Duration // how long image pulls are allowed to fail; 0 disables
+}
+
+func DefaultPodFailureOpts() PodFailureOpts {
+	return PodFailureOpts{RestartThreshold: 5, ImagePullDuration: 5 * time.Minute}
+}
+
 // https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle/
 type CoreV1Pod struct {
-	resource ctlres.Resource
+	resource    ctlres.Resource
+	failureOpts PodFailureOpts
 }
 
-func NewCoreV1Pod(resource ctlres.Resource) *CoreV1Pod {
+func NewCoreV1Pod(resource ctlres.Resource, failureOpts PodFailureOpts) *CoreV1Pod {
 	matcher := ctlres.APIVersionKindMatcher{
 		APIVersion: "v1",
 		Kind:       "Pod",
 	}
 	if matcher.Matches(resource) {
-		return &CoreV1Pod{resource}
+		return &CoreV1Pod{resource, failureOpts}
 	}
 	return nil
 }
@@ -31,7 +51,17 @@ func (s CoreV1Pod) IsDoneApplying() DoneApplyState {
 		return DoneApplyState{Done: true, Successful: false, Message: fmt.Sprintf("Error: Failed obj conversion: %s", err)}
 	}
 
-	// TODO deal with failure scenarios (retry, timeout?)
+	switch pod.Status.Phase {
+	case "Pending", "Running":
+		failed, msg, err := s.failedDetailsReason(pod)
+		if err != nil {
+			return DoneApplyState{Done: true, Successful: false, Message: fmt.Sprintf("Error: %s", err)}
+		}
+		if failed {
+			return DoneApplyState{Done: true, Successful: false, Message: msg}
+		}
+	}
+
 	switch pod.Status.Phase {
 	// Pending: The Pod has been accepted by the Kubernetes system, but one or more of the
 	// Container images has not been created. This includes time before being scheduled as
@@ -103,6 +133,147 @@ func (s CoreV1Pod) pendingDetailsReason(pod corev1.Pod) string {
 	return ""
 }
 
+// failedDetailsReason detects containers that are not likely to recover
+// so that waiting could fail early instead of waiting for a timeout
+func (s CoreV1Pod) failedDetailsReason(pod corev1.Pod) (bool, string, error) {
+	restartThreshold := s.failureOpts.RestartThreshold
+	imagePullDuration := s.failureOpts.ImagePullDuration
+
+	if val, found := pod.Annotations[coreV1PodWaitFailureRestartThresholdAnnKey]; found {
+		var err error
+		restartThreshold, err = strconv.Atoi(val)
+		if err != nil {
+			return false, "", fmt.Errorf("Failed to parse %s: %s", coreV1PodWaitFailureRestartThresholdAnnKey, err)
+		}
+	}
+
+	if val, found := pod.Annotations[coreV1PodWaitFailureImagePullDurationAnnKey]; found {
+		var err error
+		imagePullDuration, err = time.ParseDuration(val)
+		if err != nil {
+			return false, "", fmt.Errorf("Failed to parse %s: %s", coreV1PodWaitFailureImagePullDurationAnnKey, err)
+		}
+	}
+
+	statuses := append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+
+	for i, st := range statuses {
+		if st.State.Waiting == nil {
+			continue
+		}
+
+		reason := st.State.Waiting.Reason
+		if len(st.State.Waiting.Message) > 0 {
+			reason += fmt.Sprintf(" (message: %s)", st.State.Waiting.Message)
+		}
+
+		switch st.State.Waiting.Reason {
+		case "InvalidImageName":
+			return true, fmt.Sprintf("Container '%s' has invalid image: %s", st.Name, reason), nil
+
+		case "ErrImagePull", "ImagePullBackOff":
+			pullStartTime := s.imagePullStartTime(pod, i)
+			if imagePullDuration > 0 && pullStartTime != nil && time.Now().Sub(*pullStartTime) > imagePullDuration {
+				return true, fmt.Sprintf("Container '%s' failed to pull image for more than %s: %s",
+					st.Name, imagePullDuration, reason), nil
+			}
+
+		case "CrashLoopBackOff":
+			if restartThreshold > 0 && int(st.RestartCount) >= restartThreshold {
+				if st.LastTerminationState.Terminated != nil {
+					reason += fmt.Sprintf(" (last exit code: %d, reason: %s)",
+						st.LastTerminationState.Terminated.ExitCode, st.LastTerminationState.Terminated.Reason)
+				}
+				return true, fmt.Sprintf("Container '%s' is crash looping (restarted %d times): %s",
+					st.Name, st.RestartCount, reason), nil
+			}
+		}
+	}
+
+	return false, "", nil
+}
+
+// imagePullStartTime approximates when container (i-th out of init and regular
+// containers) started pulling its image since waiting state does not record
+// when it began: containers are started once preceding init containers finish
+// (or all of them for regular containers), and are pulled again after restarts
+func (s CoreV1Pod) imagePullStartTime(pod corev1.Pod, i int) *time.Time {
+	if pod.Status.StartTime == nil {
+		return nil
+	}
+
+	startTime := pod.Status.StartTime.Time
+
+	laterStartTime := func(t metav1.Time) {
+		if t.Time.After(startTime) {
+			startTime = t.Time
+		}
+	}
+
+	numInit := len(pod.Status.InitContainerStatuses)
+
+	switch {
+	case i > 0 && i < numInit:
+		if prevTerminated := pod.Status.InitContainerStatuses[i-1].State.Terminated; prevTerminated != nil {
+			laterStartTime(prevTerminated.FinishedAt)
+		}
+
+	case i >= numInit:
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == corev1.PodInitialized && cond.Status == corev1.ConditionTrue {
+				laterStartTime(cond.LastTransitionTime)
+			}
+		}
+	}
+
+	var st corev1.ContainerStatus
+	if i < numInit {
+		st = pod.Status.InitContainerStatuses[i]
+	} else {
+		st = pod.Status.ContainerStatuses[i-numInit]
+	}
+
+	if st.LastTerminationState.Terminated != nil {
+		laterStartTime(st.LastTerminationState.Terminated.FinishedAt)
+	}
+
+	return &startTime
+}
+
+// failedPodState returns failure state of the first Pod (out of given
+// resources) selected by given function that has containers that are not
+// likely to recover. Pods that failed or were evicted as a whole are
+// expected to be replaced by controllers, hence are not considered.
+func failedPodState(resources []ctlres.Resource, failureOpts PodFailureOpts,
+	selectFunc func(corev1.Pod) bool) *DoneApplyState {
+
+	for _, res := range resources {
+		podRes := NewCoreV1Pod(res, failureOpts)
+		if podRes == nil {
+			continue
+		}
+
+		pod := corev1.Pod{}
+
+		err := res.AsTypedObj(&pod)
+		if err != nil || !selectFunc(pod) {
+			continue
+		}
+
+		switch pod.Status.Phase {
+		case "Pending", "Running":
+			failed, msg, err := podRes.failedDetailsReason(pod)
+			if err == nil && failed {
+				return &DoneApplyState{Done: true, Successful: false, Message: fmt.Sprintf(
+					"Pod '%s' failed: %s", pod.Name, msg)}
+			}
+		}
+	}
+
+	return nil
+}
+
 /*
 
 # Image cannot be pulled
@@ -0,0 +1,162 @@
+package resourcesmisc_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	ctlresm "github.com/k14s/kapp/pkg/kapp/resourcesmisc"
+)
+
+func TestCoreV1PodCrashLoopBackOff(t *testing.T) {
+	configYAML := `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: app
+  annotations:
+    kapp.k14s.io/core-v1-pod-wait-failure-restart-threshold: "3"
+status:
+  phase: Running
+  containerStatuses:
+  - name: app
+    restartCount: 2
+    lastState:
+      terminated:
+        exitCode: 1
+        reason: Error
+    state:
+      waiting:
+        reason: CrashLoopBackOff
+`
+
+	state := buildPod(configYAML, t).IsDoneApplying()
+	if state.Done {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	configYAML = strings.Replace(configYAML, "restartCount: 2", "restartCount: 3", -1)
+
+	state = buildPod(configYAML, t).IsDoneApplying()
+	expectedState := ctlresm.DoneApplyState{
+		Done:       true,
+		Successful: false,
+		Message:    "Container 'app' is crash looping (restarted 3 times): CrashLoopBackOff (last exit code: 1, reason: Error)",
+	}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	configYAML = strings.Replace(configYAML, `threshold: "3"`, `threshold: "0"`, -1)
+
+	state = buildPod(configYAML, t).IsDoneApplying()
+	if state.Done {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+}
+
+func TestCoreV1PodImagePullFailures(t *testing.T) {
+	configYAML := `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: app
+status:
+  phase: Pending
+  startTime: "2019-07-16T23:51:54Z"
+  containerStatuses:
+  - name: app
+    state:
+      waiting:
+        reason: InvalidImageName
+        message: bad image
+`
+
+	state := buildPod(configYAML, t).IsDoneApplying()
+	expectedState := ctlresm.DoneApplyState{
+		Done:       true,
+		Successful: false,
+		Message:    "Container 'app' has invalid image: InvalidImageName (message: bad image)",
+	}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	configYAML = strings.Replace(configYAML, "reason: InvalidImageName", "reason: ImagePullBackOff", -1)
+
+	state = buildPod(configYAML, t).IsDoneApplying()
+	expectedState = ctlresm.DoneApplyState{
+		Done:       true,
+		Successful: false,
+		Message:    "Container 'app' failed to pull image for more than 5m0s: ImagePullBackOff (message: bad image)",
+	}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+}
+
+func TestCoreV1PodImagePullFailuresAfterInitContainers(t *testing.T) {
+	recentTime := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+
+	configYAML := `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: app
+status:
+  phase: Pending
+  startTime: "2019-07-16T23:51:54Z"
+  conditions:
+  - type: Initialized
+    status: "True"
+    lastTransitionTime: "` + recentTime + `"
+  initContainerStatuses:
+  - name: init
+    state:
+      terminated:
+        exitCode: 0
+        finishedAt: "` + recentTime + `"
+  containerStatuses:
+  - name: app
+    state:
+      waiting:
+        reason: ImagePullBackOff
+`
+
+	// Image pull started after long running init container finished
+	state := buildPod(configYAML, t).IsDoneApplying()
+	expectedState := ctlresm.DoneApplyState{Done: false, Message: "Pending: ImagePullBackOff"}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	configYAML = strings.Replace(configYAML, recentTime, "2019-07-16T23:52:54Z", -1)
+
+	state = buildPod(configYAML, t).IsDoneApplying()
+	expectedState = ctlresm.DoneApplyState{
+		Done:       true,
+		Successful: false,
+		Message:    "Container 'app' failed to pull image for more than 5m0s: ImagePullBackOff",
+	}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	res := ctlres.MustNewResourceFromBytes([]byte(configYAML))
+
+	state = ctlresm.NewCoreV1Pod(res, ctlresm.PodFailureOpts{}).IsDoneApplying()
+	expectedState = ctlresm.DoneApplyState{Done: false, Message: "Pending: ImagePullBackOff"}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+}
+
+func buildPod(resourcesBs string, t *testing.T) *ctlresm.CoreV1Pod {
+	newResources, err := ctlres.NewFileResource(ctlres.NewBytesSource([]byte(resourcesBs))).Resources()
+	if err != nil {
+		t.Fatalf("Expected resources to parse")
+	}
+
+	return ctlresm.NewCoreV1Pod(newResources[0], ctlresm.DefaultPodFailureOpts())
+}
@@ -0,0 +1,147 @@
+package resourcesmisc
+
+import (
+	"fmt"
+
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CustomWaitingResource struct {
+	resource ctlres.Resource
+	waitRule ctlconf.WaitRule
+}
+
+func NewCustomWaitingResource(resource ctlres.Resource, waitRules []ctlconf.WaitRule) *CustomWaitingResource {
+	matchedRule := matchingWaitRule(resource, waitRules)
+	if matchedRule != nil && matchedRule.ExternalCheck == nil {
+		return &CustomWaitingResource{resource, *matchedRule}
+	}
+	return nil
+}
+
+func matchingWaitRule(resource ctlres.Resource, waitRules []ctlconf.WaitRule) *ctlconf.WaitRule {
+	var matchedRule *ctlconf.WaitRule
+
+	// Last matching rule wins so that user provided rules
+	// can override rules that came before them (e.g. defaults)
+	for _, rule := range waitRules {
+		if !rule.DefinesWaiting() {
+			continue
+		}
+		matchers := ctlconf.ResourceMatchers(rule.ResourceMatchers).AsResourceMatchers()
+		if (ctlres.AnyMatcher{matchers}).Matches(resource) {
+			rule := rule
+			matchedRule = &rule
+		}
+	}
+
+	return matchedRule
+}
+
+type customWaitingResourceObj struct {
+	metav1.ObjectMeta `json:"metadata"`
+
+	Status struct {
+		ObservedGeneration int64 `json:"observedGeneration"`
+	} `json:"status"`
+}
+
+func (s CustomWaitingResource) IsDoneApplying() DoneApplyState {
+	obj := customWaitingResourceObj{}
+
+	err := s.resource.AsUncheckedTypedObj(&obj)
+	if err != nil {
+		return DoneApplyState{Done: true, Successful: false, Message: fmt.Sprintf(
+			"Error: Failed obj conversion: %s", err)}
+	}
+
+	if s.waitRule.SupportsObservedGeneration && obj.Generation != obj.Status.ObservedGeneration {
+		return DoneApplyState{Done: false, Message: fmt.Sprintf(
+			"Waiting for generation %d to be observed", obj.Generation)}
+	}
+
+	conds := Conditions{s.resource}.All()
+
+	for _, condMatcher := range s.waitRule.ConditionMatchers {
+		for _, cond := range conds {
+			if cond.Type != condMatcher.Type || cond.Status != condMatcher.Status {
+				continue
+			}
+
+			// Skip conditions that were set for previous generations
+			if s.waitRule.SupportsObservedGeneration && cond.ObservedGeneration != 0 &&
+				cond.ObservedGeneration != obj.Generation {
+				continue
+			}
+
+			desc := fmt.Sprintf("condition %s is %s", cond.Type, cond.Status)
+			if len(cond.Reason) > 0 || len(cond.Message) > 0 {
+				desc += fmt.Sprintf(": %s (message: %s)", cond.Reason, cond.Message)
+			}
+
+			switch {
+			case condMatcher.Failure:
+				return DoneApplyState{Done: true, Successful: false, Message: "Encountered failure " + desc}
+			case condMatcher.Success:
+				return DoneApplyState{Done: true, Successful: true, Message: "Encountered successful " + desc}
+			default:
+				return DoneApplyState{Done: false, Message: "Waiting since " + desc}
+			}
+		}
+	}
+
+	for _, fieldMatcher := range s.waitRule.FieldMatchers {
+		val, found, err := s.fieldValue(fieldMatcher.Path, fieldMatcher.Value)
+		if err != nil {
+			return DoneApplyState{Done: true, Successful: false, Message: fmt.Sprintf(
+				"Error: Failed to find field '%s': %s", fieldMatcher.Path.AsString(), err)}
+		}
+		if !found {
+			continue
+		}
+
+		desc := fmt.Sprintf("field %s is %s", fieldMatcher.Path.AsString(), val)
+
+		switch {
+		case fieldMatcher.Failure:
+			return DoneApplyState{Done: true, Successful: false, Message: "Encountered failure " + desc}
+		case fieldMatcher.Success:
+			return DoneApplyState{Done: true, Successful: true, Message: "Encountered successful " + desc}
+		default:
+			return DoneApplyState{Done: false, Message: "Waiting since " + desc}
+		}
+	}
+
+	// Rule that only checks observed generation is satisfied at this point
+	if len(s.waitRule.ConditionMatchers) == 0 && len(s.waitRule.FieldMatchers) == 0 {
+		return DoneApplyState{Done: true, Successful: true}
+	}
+
+	return DoneApplyState{Done: false, Message: "No failing or successful conditions found"}
+}
+
+// fieldValue returns string form of first value at path that matches expected value
+// (path may match multiple locations, e.g. when it includes allIndexes)
+func (s CustomWaitingResource) fieldValue(path ctlres.Path, expectedVal string) (string, bool, error) {
+	vals, err := path.FindValues(s.resource.DeepCopyRaw())
+	if err != nil {
+		return "", false, err
+	}
+
+	for _, val := range vals {
+		switch val.(type) {
+		case map[string]interface{}, []interface{}:
+			return "", false, fmt.Errorf("Expected value to be a scalar, but was %T", val)
+		case nil:
+			continue
+		default:
+			if strVal := fmt.Sprintf("%v", val); strVal == expectedVal {
+				return strVal, true, nil
+			}
+		}
+	}
+
+	return "", false, nil
+}
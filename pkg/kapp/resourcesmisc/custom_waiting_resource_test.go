@@ -0,0 +1,200 @@
+package resourcesmisc_test
+
+import (
+	"strings"
+	"testing"
+
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	ctlresm "github.com/k14s/kapp/pkg/kapp/resourcesmisc"
+)
+
+func TestCustomWaitingResourceConditions(t *testing.T) {
+	configYAML := `
+apiVersion: kapp.k14s.io/v1alpha1
+kind: Config
+waitRules:
+- supportsObservedGeneration: true
+  conditionMatchers:
+  - type: Failed
+    status: "True"
+    failure: true
+  - type: Ready
+    status: "True"
+    success: true
+  resourceMatchers:
+  - apiVersionKindMatcher: {apiVersion: example.com/v1, kind: Widget}
+`
+
+	resYAML := `
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: widget
+  generation: 2
+status:
+  observedGeneration: 1
+  conditions:
+  - type: Ready
+    status: "True"
+`
+
+	state := buildCustomWaitingRes(resYAML, configYAML, t).IsDoneApplying()
+	expectedState := ctlresm.DoneApplyState{
+		Done:       false,
+		Successful: false,
+		Message:    "Waiting for generation 2 to be observed",
+	}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	resYAML = strings.Replace(resYAML, "observedGeneration: 1", "observedGeneration: 2", -1)
+
+	state = buildCustomWaitingRes(resYAML, configYAML, t).IsDoneApplying()
+	expectedState = ctlresm.DoneApplyState{
+		Done:       true,
+		Successful: true,
+		Message:    "Encountered successful condition Ready is True",
+	}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	resYAML = strings.Replace(resYAML, "type: Ready", "type: Failed\n    reason: Boom\n    message: msg", -1)
+
+	state = buildCustomWaitingRes(resYAML, configYAML, t).IsDoneApplying()
+	expectedState = ctlresm.DoneApplyState{
+		Done:       true,
+		Successful: false,
+		Message:    "Encountered failure condition Failed is True: Boom (message: msg)",
+	}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+}
+
+func TestCustomWaitingResourceFields(t *testing.T) {
+	configYAML := `
+apiVersion: kapp.k14s.io/v1alpha1
+kind: Config
+waitRules:
+- fieldMatchers:
+  - path: [status, phase]
+    value: Ready
+    success: true
+  resourceMatchers:
+  - apiVersionKindMatcher: {apiVersion: example.com/v1, kind: Widget}
+`
+
+	resYAML := `
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: widget
+status:
+  phase: Pending
+`
+
+	state := buildCustomWaitingRes(resYAML, configYAML, t).IsDoneApplying()
+	expectedState := ctlresm.DoneApplyState{
+		Done:       false,
+		Successful: false,
+		Message:    "No failing or successful conditions found",
+	}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	resYAML = strings.Replace(resYAML, "phase: Pending", "phase: Ready", -1)
+
+	state = buildCustomWaitingRes(resYAML, configYAML, t).IsDoneApplying()
+	expectedState = ctlresm.DoneApplyState{
+		Done:       true,
+		Successful: true,
+		Message:    "Encountered successful field status,phase is Ready",
+	}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+}
+
+func TestCustomWaitingResourceFieldsMultipleLocations(t *testing.T) {
+	configYAML := `
+apiVersion: kapp.k14s.io/v1alpha1
+kind: Config
+waitRules:
+- fieldMatchers:
+  - path: [status, replicas, {allIndexes: true}, phase]
+    value: Failed
+    failure: true
+  - path: [status, replicas, {matchField: name, value: primary}, phase]
+    value: Ready
+    success: true
+  resourceMatchers:
+  - apiVersionKindMatcher: {apiVersion: example.com/v1, kind: Database}
+`
+
+	resYAML := `
+apiVersion: example.com/v1
+kind: Database
+metadata:
+  name: db
+status:
+  replicas:
+  - name: primary
+    phase: Pending
+  - name: secondary
+    phase: Ready
+`
+
+	state := buildCustomWaitingRes(resYAML, configYAML, t).IsDoneApplying()
+	expectedState := ctlresm.DoneApplyState{
+		Done:       false,
+		Successful: false,
+		Message:    "No failing or successful conditions found",
+	}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	resYAML = strings.Replace(resYAML, "phase: Pending", "phase: Ready", -1)
+
+	state = buildCustomWaitingRes(resYAML, configYAML, t).IsDoneApplying()
+	expectedState = ctlresm.DoneApplyState{
+		Done:       true,
+		Successful: true,
+		Message:    "Encountered successful field status,replicas,(name=primary),phase is Ready",
+	}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	resYAML = strings.Replace(resYAML, "phase: Ready", "phase: Failed", 1)
+
+	state = buildCustomWaitingRes(resYAML, configYAML, t).IsDoneApplying()
+	expectedState = ctlresm.DoneApplyState{
+		Done:       true,
+		Successful: false,
+		Message:    "Encountered failure field status,replicas,(all),phase is Failed",
+	}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+}
+
+func buildCustomWaitingRes(resYAML, configYAML string, t *testing.T) *ctlresm.CustomWaitingResource {
+	config, err := ctlconf.NewConfigFromResource(ctlres.MustNewResourceFromBytes([]byte(configYAML)))
+	if err != nil {
+		t.Fatalf("Expected config to parse: %s", err)
+	}
+
+	res := ctlres.MustNewResourceFromBytes([]byte(resYAML))
+
+	waitingRes := ctlresm.NewCustomWaitingResource(res, config.WaitRules)
+	if waitingRes == nil {
+		t.Fatalf("Expected wait rule to match resource")
+	}
+
+	return waitingRes
+}
@@ -0,0 +1,63 @@
+package resourcesmisc
+
+import (
+	"fmt"
+
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	extAndNetworkingVxIngressWaitLoadBalancerAnnKey = "kapp.k14s.io/ingress-wait-load-balancer-ingress" // values: "true" (default), "false"
+)
+
+type ExtensionsAndNetworkingVxIngress struct {
+	resource ctlres.Resource
+}
+
+func NewExtensionsAndNetworkingVxIngress(resource ctlres.Resource) *ExtensionsAndNetworkingVxIngress {
+	extMatcher := ctlres.APIGroupKindMatcher{
+		APIGroup: "extensions",
+		Kind:     "Ingress",
+	}
+	netMatcher := ctlres.APIGroupKindMatcher{
+		APIGroup: "networking.k8s.io",
+		Kind:     "Ingress",
+	}
+	if extMatcher.Matches(resource) || netMatcher.Matches(resource) {
+		return &ExtensionsAndNetworkingVxIngress{resource}
+	}
+	return nil
+}
+
+// Cannot use typed Ingress since no gurantee which versions are used
+type ingressObj struct {
+	Status struct {
+		LoadBalancer corev1.LoadBalancerStatus `json:"loadBalancer"`
+	} `json:"status"`
+}
+
+func (s ExtensionsAndNetworkingVxIngress) IsDoneApplying() DoneApplyState {
+	ing := ingressObj{}
+
+	err := s.resource.AsUncheckedTypedObj(&ing)
+	if err != nil {
+		return DoneApplyState{Done: true, Successful: false, Message: fmt.Sprintf("Error: Failed obj conversion: %s", err)}
+	}
+
+	// Some ingress controllers do not populate load balancer status
+	switch s.resource.Annotations()[extAndNetworkingVxIngressWaitLoadBalancerAnnKey] {
+	case "", "true":
+	case "false":
+		return DoneApplyState{Done: true, Successful: true}
+	default:
+		return DoneApplyState{Done: true, Successful: false, Message: fmt.Sprintf(
+			"Error: Expected annotation %s to be 'true' or 'false'", extAndNetworkingVxIngressWaitLoadBalancerAnnKey)}
+	}
+
+	if len(ing.Status.LoadBalancer.Ingress) == 0 {
+		return DoneApplyState{Done: false, Message: "Load balancer ingress is empty"}
+	}
+
+	return DoneApplyState{Done: true, Successful: true}
+}
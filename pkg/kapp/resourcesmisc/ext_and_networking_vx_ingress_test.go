@@ -0,0 +1,64 @@
+package resourcesmisc_test
+
+import (
+	"strings"
+	"testing"
+
+	ctlresm "github.com/k14s/kapp/pkg/kapp/resourcesmisc"
+)
+
+func TestExtensionsAndNetworkingVxIngressLoadBalancer(t *testing.T) {
+	ingYAML := `
+apiVersion: networking.k8s.io/v1beta1
+kind: Ingress
+metadata:
+  name: app
+status:
+  loadBalancer: {}
+`
+
+	state := ctlresm.NewExtensionsAndNetworkingVxIngress(mustNewResource(ingYAML)).IsDoneApplying()
+	expectedState := ctlresm.DoneApplyState{Done: false, Message: "Load balancer ingress is empty"}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	ingYAML = strings.Replace(ingYAML, "loadBalancer: {}", `loadBalancer:
+    ingress:
+    - ip: 10.0.0.1`, -1)
+
+	state = ctlresm.NewExtensionsAndNetworkingVxIngress(mustNewResource(ingYAML)).IsDoneApplying()
+	if state != (ctlresm.DoneApplyState{Done: true, Successful: true}) {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+}
+
+func TestExtensionsAndNetworkingVxIngressWaitLoadBalancerAnn(t *testing.T) {
+	ingYAML := `
+apiVersion: extensions/v1beta1
+kind: Ingress
+metadata:
+  name: app
+  annotations:
+    kapp.k14s.io/ingress-wait-load-balancer-ingress: "false"
+status:
+  loadBalancer: {}
+`
+
+	state := ctlresm.NewExtensionsAndNetworkingVxIngress(mustNewResource(ingYAML)).IsDoneApplying()
+	if state != (ctlresm.DoneApplyState{Done: true, Successful: true}) {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	ingYAML = strings.Replace(ingYAML, `"false"`, `"no"`, -1)
+
+	state = ctlresm.NewExtensionsAndNetworkingVxIngress(mustNewResource(ingYAML)).IsDoneApplying()
+	expectedState := ctlresm.DoneApplyState{
+		Done:       true,
+		Successful: false,
+		Message:    "Error: Expected annotation kapp.k14s.io/ingress-wait-load-balancer-ingress to be 'true' or 'false'",
+	}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+}
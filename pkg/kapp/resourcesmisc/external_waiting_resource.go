@@ -0,0 +1,105 @@
+package resourcesmisc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+)
+
+const (
+	externalWaitingResourceDefaultTimeout = 30 * time.Second
+)
+
+type ExternalWaitingResource struct {
+	resource     ctlres.Resource
+	associatedRs []ctlres.Resource
+	check        ctlconf.WaitRuleExternalCheck
+	runCheck     bool
+}
+
+// NewExternalWaitingResource returns waiter for resources matched by wait rules with
+// external checks. Checks are only executed if runCheck is set (i.e. while waiting),
+// otherwise resource is reported as not done (e.g. when showing changes).
+func NewExternalWaitingResource(resource ctlres.Resource, associatedRs []ctlres.Resource,
+	waitRules []ctlconf.WaitRule, runCheck bool) *ExternalWaitingResource {
+
+	matchedRule := matchingWaitRule(resource, waitRules)
+	if matchedRule != nil && matchedRule.ExternalCheck != nil {
+		return &ExternalWaitingResource{resource, associatedRs, *matchedRule.ExternalCheck, runCheck}
+	}
+	return nil
+}
+
+type externalWaitingResourceInput struct {
+	Resource            map[string]interface{}   `json:"resource"`
+	AssociatedResources []map[string]interface{} `json:"associatedResources"`
+}
+
+func (s ExternalWaitingResource) IsDoneApplying() DoneApplyState {
+	if !s.runCheck {
+		return DoneApplyState{Done: false, Message: fmt.Sprintf(
+			"External check '%s' is only run while waiting", s.check.Command)}
+	}
+
+	input := externalWaitingResourceInput{
+		Resource:            s.resource.DeepCopyRaw(),
+		AssociatedResources: []map[string]interface{}{},
+	}
+
+	for _, res := range s.associatedRs {
+		input.AssociatedResources = append(input.AssociatedResources, res.DeepCopyRaw())
+	}
+
+	inputBytes, err := json.Marshal(input)
+	if err != nil {
+		return DoneApplyState{Done: true, Successful: false, Message: fmt.Sprintf(
+			"Error: Failed to serialize external check input: %s", err)}
+	}
+
+	timeout := externalWaitingResourceDefaultTimeout
+	if s.check.Timeout != nil {
+		timeout = s.check.Timeout.Duration
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, s.check.Command, s.check.Args...)
+	cmd.Stdin = bytes.NewReader(inputBytes)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+
+	// Timed out checks are retried since they may be caused
+	// by temporary conditions (e.g. slow network calls)
+	if ctx.Err() == context.DeadlineExceeded {
+		return DoneApplyState{Done: false, Message: fmt.Sprintf(
+			"External check '%s' timed out after %s", s.check.Command, timeout)}
+	}
+
+	if err != nil {
+		return DoneApplyState{Done: true, Successful: false, Message: fmt.Sprintf(
+			"Error: External check '%s' failed: %s (stderr: %s)",
+			s.check.Command, err, strings.TrimSpace(stderr.String()))}
+	}
+
+	var state DoneApplyState
+
+	err = json.Unmarshal(stdout.Bytes(), &state)
+	if err != nil {
+		return DoneApplyState{Done: true, Successful: false, Message: fmt.Sprintf(
+			"Error: Failed to parse output of external check '%s': %s", s.check.Command, err)}
+	}
+
+	return state
+}
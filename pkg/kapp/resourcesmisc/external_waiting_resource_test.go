@@ -0,0 +1,82 @@
+package resourcesmisc_test
+
+import (
+	"strings"
+	"testing"
+
+	ctlconf "github.com/k14s/kapp/pkg/kapp/config"
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+	ctlresm "github.com/k14s/kapp/pkg/kapp/resourcesmisc"
+)
+
+func TestExternalWaitingResource(t *testing.T) {
+	configYAML := `
+apiVersion: kapp.k14s.io/v1alpha1
+kind: Config
+waitRules:
+- externalCheck:
+    command: /bin/sh
+    args:
+    - -c
+    - 'grep -q "\"phase\":\"Ready\"" && echo "{\"done\":true,\"successful\":true,\"message\":\"ok\"}" || echo "{\"done\":false}"'
+  resourceMatchers:
+  - apiVersionKindMatcher: {apiVersion: example.com/v1, kind: Widget}
+`
+
+	resYAML := `
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: widget
+status:
+  phase: Pending
+`
+
+	state := buildExternalWaitingRes(resYAML, configYAML, true, t).IsDoneApplying()
+	expectedState := ctlresm.DoneApplyState{Done: false}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	resYAML = strings.Replace(resYAML, "phase: Pending", "phase: Ready", -1)
+
+	state = buildExternalWaitingRes(resYAML, configYAML, true, t).IsDoneApplying()
+	expectedState = ctlresm.DoneApplyState{Done: true, Successful: true, Message: "ok"}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	// Checks are not run outside of waiting (e.g. when showing changes)
+	state = buildExternalWaitingRes(resYAML, configYAML, false, t).IsDoneApplying()
+	expectedState = ctlresm.DoneApplyState{Done: false, Message: "External check '/bin/sh' is only run while waiting"}
+	if state != expectedState {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+
+	configYAML = strings.Replace(configYAML, "grep -q", "exit 1; grep -q", -1)
+
+	state = buildExternalWaitingRes(resYAML, configYAML, true, t).IsDoneApplying()
+	if !state.Done || state.Successful || !strings.HasPrefix(state.Message, "Error: External check '/bin/sh' failed") {
+		t.Fatalf("Found incorrect state: %#v", state)
+	}
+}
+
+func buildExternalWaitingRes(resYAML, configYAML string, runCheck bool, t *testing.T) *ctlresm.ExternalWaitingResource {
+	config, err := ctlconf.NewConfigFromResource(ctlres.MustNewResourceFromBytes([]byte(configYAML)))
+	if err != nil {
+		t.Fatalf("Expected config to parse: %s", err)
+	}
+
+	res := ctlres.MustNewResourceFromBytes([]byte(resYAML))
+
+	if ctlresm.NewCustomWaitingResource(res, config.WaitRules) != nil {
+		t.Fatalf("Expected custom waiting resource to not handle external checks")
+	}
+
+	waitingRes := ctlresm.NewExternalWaitingResource(res, nil, config.WaitRules, runCheck)
+	if waitingRes == nil {
+		t.Fatalf("Expected wait rule to match resource")
+	}
+
+	return waitingRes
+}
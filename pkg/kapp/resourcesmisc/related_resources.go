@@ -0,0 +1,12 @@
+package resourcesmisc
+
+import (
+	ctlres "github.com/k14s/kapp/pkg/kapp/resources"
+)
+
+// RelatedResources provides access to resources that are not associated
+// with waited resource but determine its waiting state
+// (e.g. StorageClass of a PersistentVolumeClaim)
+type RelatedResources interface {
+	Get(apiVersion, kind, namespace, name string) (ctlres.Resource, error)
+}